@@ -36,12 +36,69 @@ const (
 	ResourcePhaseDeleted ResourcePhase = "Deleted"
 	// ResourcePhaseFailed indicates the resource has failed
 	ResourcePhaseFailed ResourcePhase = "Failed"
+	// ResourcePhaseWaiting indicates the resource is waiting for the resources it references
+	ResourcePhaseWaiting ResourcePhase = "Waiting"
 )
 
 // Condition types for resources
 const (
 	// ConditionTypeSynchronized indicates whether the resource is synchronized with the remote system
 	ConditionTypeSynchronized = "Synchronized"
+	// ConditionTypeDrifted indicates whether the remote resource differs from the desired spec
+	ConditionTypeDrifted = "Drifted"
+	// ConditionTypeDependenciesReady indicates whether all referenced resources are provisioned
+	ConditionTypeDependenciesReady = "DependenciesReady"
+	// ConditionTypeDeletionInProgress indicates that the remote resource was asked to be deleted and is not gone yet
+	ConditionTypeDeletionInProgress = "DeletionInProgress"
+	// ConditionTypeAPIAvailable indicates whether the Aruba API gateway is reachable, reconciliation pauses while it is not
+	ConditionTypeAPIAvailable = "APIAvailable"
+	// ConditionTypeCredentialsReady indicates whether the credentials Secret of a ProviderConfig can be used
+	ConditionTypeCredentialsReady = "CredentialsReady"
+)
+
+// Annotations understood by all resources
+const (
+	// AnnotationRetry requests an immediate retry of a resource in the Failed phase.
+	// The annotation is removed by the controller once the retry has been scheduled.
+	AnnotationRetry = "arubacloud.com/retry"
+	// AnnotationDriftPolicy selects how the controller reacts to out-of-band changes of the remote resource.
+	// Valid values are Ignore, Detect and Correct; Detect is used when the annotation is missing or invalid.
+	AnnotationDriftPolicy = "arubacloud.com/drift-policy"
+	// AnnotationDeletionPolicy selects what happens to the remote resource when the object is deleted.
+	// Valid values are Delete, Orphan and Retain; Delete is used when the annotation is missing or invalid.
+	AnnotationDeletionPolicy = "arubacloud.com/deletion-policy"
+	// AnnotationImportID adopts the existing remote resource with the given ID instead of creating a new one
+	AnnotationImportID = "arubacloud.com/import-id"
+	// AnnotationDefaultTenant, AnnotationDefaultLocation and AnnotationDefaultDataCenter set on a Project or on
+	// a Namespace provide the values filled in by the defaulting webhook when a spec leaves them empty
+	AnnotationDefaultTenant     = "arubacloud.com/default-tenant"
+	AnnotationDefaultLocation   = "arubacloud.com/default-location"
+	AnnotationDefaultDataCenter = "arubacloud.com/default-data-center"
+)
+
+// DriftPolicy defines how drift between the spec and the remote resource is handled
+type DriftPolicy string
+
+const (
+	// DriftPolicyIgnore disables drift detection
+	DriftPolicyIgnore DriftPolicy = "Ignore"
+	// DriftPolicyDetect reports drift through the Drifted condition without changing the remote resource
+	DriftPolicyDetect DriftPolicy = "Detect"
+	// DriftPolicyCorrect reports drift and reapplies the spec, recreating the remote resource if it was deleted
+	DriftPolicyCorrect DriftPolicy = "Correct"
+)
+
+// DeletionPolicy defines what happens to the remote resource when the object is deleted
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the remote resource before the object is removed
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan removes the object and leaves the remote resource untouched
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+	// DeletionPolicyRetain protects the remote resource: the object is kept in the Deleting phase
+	// until the policy is changed to Delete or Orphan
+	DeletionPolicyRetain DeletionPolicy = "Retain"
 )
 
 // Location specifies the location for resources
@@ -56,11 +113,45 @@ type ResourceReference struct {
 	// Name is the name of the referenced resource
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
-	// Namespace is the namespace of the referenced resource
-	// +kubebuilder:validation:Required
+	// Namespace is the namespace of the referenced resource, defaults to the namespace of the referencing object
+	// +kubebuilder:validation:Optional
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// APIErrorStatus is an error returned by the Aruba API
+type APIErrorStatus struct {
+	// Type is the URI identifying the kind of error
+	// +kubebuilder:validation:Optional
+	Type string `json:"type,omitempty"`
+
+	// Title is a short summary of the error
+	// +kubebuilder:validation:Optional
+	Title string `json:"title,omitempty"`
+
+	// Status is the HTTP status code of the response
+	// +kubebuilder:validation:Optional
+	Status int32 `json:"status,omitempty"`
+
+	// TraceID identifies the failed request, quote it when contacting Aruba support
+	// +kubebuilder:validation:Optional
+	TraceID string `json:"traceId,omitempty"`
+
+	// FieldErrors are the validation errors of the request, by spec field
+	// +kubebuilder:validation:Optional
+	FieldErrors []FieldError `json:"fieldErrors,omitempty"`
+}
+
+// FieldError is a validation error of a single field
+type FieldError struct {
+	// Field is the path of the invalid field in the resource (e.g. spec.sizeGb)
+	// +kubebuilder:validation:Optional
+	Field string `json:"field,omitempty"`
+
+	// Message describes why the field is invalid
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
 // Common status for all resources
 type ResourceStatus struct {
 	// Phase represents the current phase of the resource
@@ -83,6 +174,14 @@ type ResourceStatus struct {
 	// +kubebuilder:validation:Optional
 	PhaseStartTime *metav1.Time `json:"phaseStartTime,omitempty"`
 
+	// RetryCount is the number of consecutive attempts made to recover the resource from the Failed phase
+	// +kubebuilder:validation:Optional
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// LastError is the last error returned by the Aruba API, cleared once the resource is created or updated
+	// +kubebuilder:validation:Optional
+	LastError *APIErrorStatus `json:"lastError,omitempty"`
+
 	// Conditions represent the latest available observations of the Resource state
 	// +listType=map
 	// +listMapKey=type
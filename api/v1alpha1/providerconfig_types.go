@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CredentialsSecretReference references the Secret holding the Keycloak client credentials of a tenant
+type CredentialsSecretReference struct {
+	// Name of the Secret
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the Secret
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// ClientIDKey is the key of the client ID in the Secret
+	// +kubebuilder:default=client-id
+	// +kubebuilder:validation:Optional
+	ClientIDKey string `json:"clientIDKey,omitempty"`
+
+	// ClientSecretKey is the key of the client secret in the Secret
+	// +kubebuilder:default=client-secret
+	// +kubebuilder:validation:Optional
+	ClientSecretKey string `json:"clientSecretKey,omitempty"`
+}
+
+// ProviderConfigSpec defines the desired state of ProviderConfig.
+type ProviderConfigSpec struct {
+	// Tenant is the tenant whose resources authenticate with these credentials
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Tenant string `json:"tenant"`
+
+	// CredentialsSecretRef references the Secret holding the client credentials of the tenant
+	// +kubebuilder:validation:Required
+	CredentialsSecretRef CredentialsSecretReference `json:"credentialsSecretRef"`
+
+	// APIGateway overrides the API gateway URL of the operator configuration for this tenant
+	// +kubebuilder:validation:Optional
+	APIGateway string `json:"apiGateway,omitempty"`
+
+	// KeycloakURL overrides the Keycloak URL of the operator configuration for this tenant
+	// +kubebuilder:validation:Optional
+	KeycloakURL string `json:"keycloakURL,omitempty"`
+
+	// AllowedNamespaces restricts the namespaces whose resources can use this tenant, all when empty
+	// +kubebuilder:validation:Optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// ProviderConfigStatus defines the observed state of ProviderConfig.
+type ProviderConfigStatus struct {
+	// ObservedGeneration is the generation of the ProviderConfig last reconciled
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// SecretResourceVersion is the resource version of the credentials Secret last used
+	// +kubebuilder:validation:Optional
+	SecretResourceVersion string `json:"secretResourceVersion,omitempty"`
+
+	// Conditions represent the latest available observations of the ProviderConfig state
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=pc
+// +kubebuilder:printcolumn:name="Tenant",type="string",JSONPath=".spec.tenant"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"CredentialsReady\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ProviderConfig is the Schema for the providerconfigs API. It maps a tenant to the
+// Kubernetes Secret holding its credentials.
+type ProviderConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProviderConfigSpec   `json:"spec,omitempty"`
+	Status ProviderConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderConfigList contains a list of ProviderConfig.
+type ProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderConfig `json:"items"`
+}
+
+// AllowsNamespace reports whether resources of the namespace can use this ProviderConfig
+func (p *ProviderConfig) AllowsNamespace(namespace string) bool {
+	return len(p.Spec.AllowedNamespaces) == 0 || slices.Contains(p.Spec.AllowedNamespaces, namespace)
+}
+
+func init() {
+	SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
+}
@@ -46,6 +46,10 @@ import (
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/controller"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/health"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/logging"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/metrics"
+	webhookv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -111,6 +115,8 @@ func main() {
 	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: level,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// Mask secrets and tokens before anything is written
+			a = logging.RedactAttr(groups, a)
 			switch a.Key {
 			case slog.TimeKey: // "time"
 				a.Key = "timestamp"
@@ -250,7 +256,26 @@ func main() {
 		os.Exit(1)
 	}
 
-	baseReconciler := reconciler.NewReconciler(mgr, mainConfig.ToReconcilerConfig())
+	baseReconciler, err := reconciler.NewReconciler(mgr, mainConfig.ToReconcilerConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create base reconciler")
+		os.Exit(1)
+	}
+
+	// Changes to the ConfigMap and Secret are applied without a restart
+	configReloader := config.NewReloader(mgr.GetAPIReader(), mgr.GetEventRecorderFor("arubacloud-resource-operator"),
+		configMapName, configNamespace, secretName, mainConfig, func(cfg *config.MainConfig) error {
+			return baseReconciler.Reconfigure(cfg.ToReconcilerConfig())
+		})
+	if err := configReloader.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "config")
+		os.Exit(1)
+	}
+
+	if err := metrics.RegisterPhaseCollector(mgr); err != nil {
+		setupLog.Error(err, "unable to register metrics collector", "collector", "resources")
+		os.Exit(1)
+	}
 
 	// Setup Project controller
 	projectReconciler := controller.NewProjectReconciler(baseReconciler)
@@ -315,6 +340,57 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup ProviderConfig controller
+	providerConfigReconciler := controller.NewProviderConfigReconciler(baseReconciler)
+	if err = providerConfigReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ProviderConfig")
+		os.Exit(1)
+	}
+
+	// Setup defaulting and validating webhooks, disabled with ENABLE_WEBHOOKS=false when no certificates are provided
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		validationOptions := mainConfig.ToValidationOptions()
+		defaultingOptions := mainConfig.ToDefaultingOptions()
+		if err = webhookv1alpha1.SetupProjectWebhookWithManager(mgr, validationOptions, defaultingOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Project")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupElasticIpWebhookWithManager(mgr, validationOptions, defaultingOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ElasticIp")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupBlockStorageWebhookWithManager(mgr, validationOptions, defaultingOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BlockStorage")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupCloudServerWebhookWithManager(mgr, validationOptions, defaultingOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CloudServer")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupKeyPairWebhookWithManager(mgr, validationOptions, defaultingOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KeyPair")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupSecurityGroupWebhookWithManager(mgr, validationOptions, defaultingOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SecurityGroup")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupSecurityRuleWebhookWithManager(mgr, validationOptions, defaultingOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SecurityRule")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupSubnetWebhookWithManager(mgr, validationOptions, defaultingOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Subnet")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupVpcWebhookWithManager(mgr, validationOptions, defaultingOptions); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Vpc")
+			os.Exit(1)
+		}
+	}
+	// +kubebuilder:scaffold:builder
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
@@ -339,6 +415,20 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("api-gateway", health.Cached(mainConfig.ReadinessCacheTTL, baseReconciler.APIGatewayCheck)); err != nil {
+		setupLog.Error(err, "unable to set up ready check", "check", "api-gateway")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("keycloak", health.Cached(mainConfig.ReadinessCacheTTL, baseReconciler.KeycloakCheck)); err != nil {
+		setupLog.Error(err, "unable to set up ready check", "check", "keycloak")
+		os.Exit(1)
+	}
+	if mainConfig.VaultIsEnabled {
+		if err := mgr.AddReadyzCheck("vault", health.Cached(mainConfig.ReadinessCacheTTL, baseReconciler.VaultCheck)); err != nil {
+			setupLog.Error(err, "unable to set up ready check", "check", "vault")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
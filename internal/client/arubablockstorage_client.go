@@ -110,9 +110,9 @@ func (c *HelperClient) DeleteBlockStorage(ctx context.Context, projectID, blockS
 // ListBlockStorages lists all block storages in a project
 func (c *HelperClient) ListBlockStorages(ctx context.Context, projectID string) (*BlockStorageListResponse, error) {
 	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Storage/blockStorages", projectID)
-	var blockStorageList BlockStorageListResponse
-	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &blockStorageList); err != nil {
+	values, err := listAll[BlockStorageResponse](ctx, c, endpoint)
+	if err != nil {
 		return nil, err
 	}
-	return &blockStorageList, nil
+	return &BlockStorageListResponse{Total: len(values), Values: values}, nil
 }
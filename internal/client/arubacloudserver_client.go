@@ -60,6 +60,7 @@ type CloudServerProperties struct {
 	KeyPair        CloudServerResourceReference   `json:"keyPair"`
 	Subnets        []CloudServerResourceReference `json:"subnets"`
 	SecurityGroups []CloudServerResourceReference `json:"securityGroups"`
+	DataVolumes    []CloudServerResourceReference `json:"dataVolumes,omitempty"`
 	IPAddress      string                         `json:"ipAddress,omitempty"`
 }
 
@@ -133,9 +134,9 @@ func (c *HelperClient) AttachDetachDataVolumes(ctx context.Context, projectID, c
 // ListCloudServers lists all cloud servers in a project
 func (c *HelperClient) ListCloudServers(ctx context.Context, projectID string) (*CloudServerListResponse, error) {
 	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Compute/cloudServers", projectID)
-	var cloudServerList CloudServerListResponse
-	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &cloudServerList); err != nil {
+	values, err := listAll[CloudServerResponse](ctx, c, endpoint)
+	if err != nil {
 		return nil, err
 	}
-	return &cloudServerList, nil
+	return &CloudServerListResponse{Total: len(values), Values: values}, nil
 }
@@ -109,9 +109,9 @@ func (c *HelperClient) DeleteKeyPair(ctx context.Context, projectID, keyPairID s
 // ListKeyPairs lists all keypairs in a project
 func (c *HelperClient) ListKeyPairs(ctx context.Context, projectID string) (*KeyPairListResponse, error) {
 	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Compute/keyPairs", projectID)
-	var keyPairList KeyPairListResponse
-	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &keyPairList); err != nil {
+	values, err := listAll[KeyPairResponse](ctx, c, endpoint)
+	if err != nil {
 		return nil, err
 	}
-	return &keyPairList, nil
+	return &KeyPairListResponse{Total: len(values), Values: values}, nil
 }
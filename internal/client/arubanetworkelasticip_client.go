@@ -110,9 +110,9 @@ func (c *HelperClient) DeleteElasticIp(ctx context.Context, projectID, elasticIp
 // ListElasticIps lists all elastic IPs in a project
 func (c *HelperClient) ListElasticIps(ctx context.Context, projectID string) (*ElasticIpListResponse, error) {
 	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Network/elasticIps", projectID)
-	var elasticIpList ElasticIpListResponse
-	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &elasticIpList); err != nil {
+	values, err := listAll[ElasticIpResponse](ctx, c, endpoint)
+	if err != nil {
 		return nil, err
 	}
-	return &elasticIpList, nil
+	return &ElasticIpListResponse{Total: len(values), Values: values}, nil
 }
@@ -26,6 +26,11 @@ type ProjectResponse struct {
 	Properties ProjectProperties `json:"properties"`
 }
 
+type ProjectListResponse struct {
+	Total  int               `json:"total"`
+	Values []ProjectResponse `json:"values"`
+}
+
 // CreateProject creates a new project via API
 func (c *HelperClient) CreateProject(ctx context.Context, req ProjectRequest) (*ProjectResponse, error) {
 	var projectResp ProjectResponse
@@ -35,6 +40,16 @@ func (c *HelperClient) CreateProject(ctx context.Context, req ProjectRequest) (*
 	return &projectResp, nil
 }
 
+// GetProject retrieves a project via API
+func (c *HelperClient) GetProject(ctx context.Context, projectID string) (*ProjectResponse, error) {
+	endpoint := fmt.Sprintf("/projects/%s", projectID)
+	var projectResp ProjectResponse
+	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &projectResp); err != nil {
+		return nil, err
+	}
+	return &projectResp, nil
+}
+
 // UpdateProject updates an existing project via API
 func (c *HelperClient) UpdateProject(ctx context.Context, projectID string, req ProjectRequest) (*ProjectResponse, error) {
 	endpoint := fmt.Sprintf("/projects/%s", projectID)
@@ -50,3 +65,12 @@ func (c *HelperClient) DeleteProject(ctx context.Context, projectID string) erro
 	endpoint := fmt.Sprintf("/projects/%s", projectID)
 	return c.DoAPIRequest(ctx, "DELETE", endpoint, nil, nil)
 }
+
+// ListProjects lists all projects
+func (c *HelperClient) ListProjects(ctx context.Context) (*ProjectListResponse, error) {
+	values, err := listAll[ProjectResponse](ctx, c, "/projects")
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectListResponse{Total: len(values), Values: values}, nil
+}
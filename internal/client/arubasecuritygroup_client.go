@@ -90,9 +90,9 @@ func (c *HelperClient) DeleteSecurityGroup(ctx context.Context, projectID, vpcID
 // ListSecurityGroups lists all security groups in a VPC
 func (c *HelperClient) ListSecurityGroups(ctx context.Context, projectID, vpcID string) (*SecurityGroupListResponse, error) {
 	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Network/vpcs/%s/securityGroups", projectID, vpcID)
-	var securityGroupList SecurityGroupListResponse
-	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &securityGroupList); err != nil {
+	values, err := listAll[SecurityGroupResponse](ctx, c, endpoint)
+	if err != nil {
 		return nil, err
 	}
-	return &securityGroupList, nil
+	return &SecurityGroupListResponse{Total: len(values), Values: values}, nil
 }
@@ -98,9 +98,9 @@ func (c *HelperClient) DeleteSecurityRule(ctx context.Context, projectID, vpcID,
 // ListSecurityRules lists all security rules in a security group
 func (c *HelperClient) ListSecurityRules(ctx context.Context, projectID, vpcID, securityGroupID string) (*SecurityRuleListResponse, error) {
 	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Network/vpcs/%s/securityGroups/%s/securityRules", projectID, vpcID, securityGroupID)
-	var securityRuleList SecurityRuleListResponse
-	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &securityRuleList); err != nil {
+	values, err := listAll[SecurityRuleResponse](ctx, c, endpoint)
+	if err != nil {
 		return nil, err
 	}
-	return &securityRuleList, nil
+	return &SecurityRuleListResponse{Total: len(values), Values: values}, nil
 }
@@ -92,9 +92,9 @@ func (c *HelperClient) DeleteSubnet(ctx context.Context, projectID, vpcID, subne
 // ListSubnets lists all subnets in a VPC
 func (c *HelperClient) ListSubnets(ctx context.Context, projectID, vpcID string) (*SubnetListResponse, error) {
 	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Network/vpcs/%s/subnets", projectID, vpcID)
-	var subnetList SubnetListResponse
-	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &subnetList); err != nil {
+	values, err := listAll[SubnetResponse](ctx, c, endpoint)
+	if err != nil {
 		return nil, err
 	}
-	return &subnetList, nil
+	return &SubnetListResponse{Total: len(values), Values: values}, nil
 }
@@ -105,9 +105,9 @@ func (c *HelperClient) DeleteVpc(ctx context.Context, projectID, vpcID string) e
 // ListVpcs lists all vpcs in a project
 func (c *HelperClient) ListVpcs(ctx context.Context, projectID string) (*VpcListResponse, error) {
 	endpoint := fmt.Sprintf("/projects/%s/providers/Aruba.Network/vpcs", projectID)
-	var vpcList VpcListResponse
-	if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &vpcList); err != nil {
+	values, err := listAll[VpcResponse](ctx, c, endpoint)
+	if err != nil {
 		return nil, err
 	}
-	return &vpcList, nil
+	return &VpcListResponse{Total: len(values), Values: values}, nil
 }
@@ -0,0 +1,233 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/metrics"
+)
+
+// maxOutages bounds the number of past outages remembered to discount phase timeouts
+const maxOutages = 64
+
+// CircuitBreakerSettings controls when the API gateway is considered unavailable
+type CircuitBreakerSettings struct {
+	// FailureThreshold is the number of consecutive failed requests that opens the circuit
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before a request is let through to probe the API
+	OpenTimeout time.Duration
+}
+
+// DefaultCircuitBreakerSettings returns the settings used when none are configured
+func DefaultCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		FailureThreshold: 5,
+		OpenTimeout:      time.Minute,
+	}
+}
+
+// CircuitOpenError is returned instead of calling the API while the circuit is open
+type CircuitOpenError struct {
+	// RetryAfter is the time left before the API is probed again
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("API gateway unavailable, circuit breaker open (retry in %s)", e.RetryAfter.Round(time.Second))
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+type outage struct {
+	start, end time.Time
+}
+
+// CircuitBreaker stops calls to the API gateway after consecutive failures, so that
+// controllers do not keep hammering it while it is down. Once OpenTimeout elapses a single
+// request probes the API: a success closes the circuit, a failure opens it again.
+// A nil CircuitBreaker never opens.
+type CircuitBreaker struct {
+	settings CircuitBreakerSettings
+	// gateway is the API gateway guarded by the breaker, as reported by the metrics
+	gateway string
+
+	mu          sync.Mutex
+	state       circuitState
+	failures    int
+	openedAt    time.Time
+	outageStart time.Time
+	outages     []outage
+}
+
+// NewCircuitBreaker creates a closed circuit breaker of the API gateway
+func NewCircuitBreaker(gateway string, settings CircuitBreakerSettings) *CircuitBreaker {
+	metrics.APIAvailable.WithLabelValues(gateway).Set(1)
+	return &CircuitBreaker{settings: settings, gateway: gateway}
+}
+
+// CircuitBreakers holds a CircuitBreaker per API gateway, so that the outage of the gateway
+// of a tenant does not pause the tenants using another one. A nil CircuitBreakers never opens.
+type CircuitBreakers struct {
+	settings CircuitBreakerSettings
+
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+// NewCircuitBreakers creates the circuit breakers of the API gateways, with the same settings
+func NewCircuitBreakers(settings CircuitBreakerSettings) *CircuitBreakers {
+	return &CircuitBreakers{settings: settings, breakers: make(map[string]*CircuitBreaker)}
+}
+
+// For returns the circuit breaker of gateway, creating it on first use
+func (b *CircuitBreakers) For(gateway string) *CircuitBreaker {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	breaker, ok := b.breakers[gateway]
+	if !ok {
+		breaker = NewCircuitBreaker(gateway, b.settings)
+		b.breakers[gateway] = breaker
+	}
+	return breaker
+}
+
+// Allow returns a CircuitOpenError when the request must not be sent
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if retryAfter := b.retryAfter(); retryAfter > 0 {
+			return &CircuitOpenError{RetryAfter: retryAfter}
+		}
+		// This request is the probe
+		b.state = circuitHalfOpen
+		b.openedAt = time.Now()
+		return nil
+	case circuitHalfOpen:
+		// A probe that never recorded its outcome (e.g. canceled) is replaced after OpenTimeout
+		if retryAfter := b.retryAfter(); retryAfter > 0 {
+			return &CircuitOpenError{RetryAfter: retryAfter}
+		}
+		b.openedAt = time.Now()
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record updates the circuit with the outcome of a request let through by Allow
+func (b *CircuitBreaker) Record(success bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if success {
+		if b.state != circuitClosed {
+			ctrl.Log.Info("API gateway available again, closing circuit breaker", "Gateway", b.gateway, "Outage", now.Sub(b.outageStart))
+			b.outages = append(b.outages, outage{start: b.outageStart, end: now})
+			if len(b.outages) > maxOutages {
+				b.outages = b.outages[len(b.outages)-maxOutages:]
+			}
+			metrics.APIAvailable.WithLabelValues(b.gateway).Set(1)
+		}
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	switch {
+	case b.state == circuitHalfOpen:
+		b.state = circuitOpen
+		b.openedAt = now
+	case b.state == circuitClosed && b.failures >= b.settings.FailureThreshold:
+		ctrl.Log.Info("API gateway unavailable, opening circuit breaker", "Gateway", b.gateway, "Failures", b.failures, "OpenTimeout", b.settings.OpenTimeout)
+		b.state = circuitOpen
+		b.openedAt = now
+		b.outageStart = now
+		metrics.APIAvailable.WithLabelValues(b.gateway).Set(0)
+	}
+}
+
+// Available reports whether the API gateway is considered reachable
+func (b *CircuitBreaker) Available() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == circuitClosed
+}
+
+// RetryAfter returns the time left before the API is probed again, zero when requests are let through
+func (b *CircuitBreaker) RetryAfter() time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != circuitOpen {
+		return 0
+	}
+	return b.retryAfter()
+}
+
+// UnavailableSince returns how long the API gateway has been unavailable since t
+func (b *CircuitBreaker) UnavailableSince(t time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	windows := b.outages
+	if b.state != circuitClosed {
+		windows = append(windows[:len(windows):len(windows)], outage{start: b.outageStart, end: now})
+	}
+
+	var total time.Duration
+	for _, w := range windows {
+		start := w.start
+		if start.Before(t) {
+			start = t
+		}
+		if w.end.After(start) {
+			total += w.end.Sub(start)
+		}
+	}
+	return total
+}
+
+func (b *CircuitBreaker) retryAfter() time.Duration {
+	return time.Until(b.openedAt.Add(b.settings.OpenTimeout))
+}
+
+// isGatewayFailure reports whether a response or error means the API gateway is not working
+func isGatewayFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
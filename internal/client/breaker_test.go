@@ -0,0 +1,119 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	breaker := client.NewCircuitBreaker("https://api.example.com", client.CircuitBreakerSettings{FailureThreshold: 2, OpenTimeout: time.Minute})
+
+	require.NoError(t, breaker.Allow())
+	breaker.Record(false)
+	require.NoError(t, breaker.Allow())
+	breaker.Record(true)
+	require.NoError(t, breaker.Allow())
+	breaker.Record(false)
+	assert.True(t, breaker.Available(), "a success resets the failure count")
+
+	breaker.Record(false)
+
+	assert.False(t, breaker.Available())
+	var circuitErr *client.CircuitOpenError
+	require.ErrorAs(t, breaker.Allow(), &circuitErr)
+	assert.Greater(t, circuitErr.RetryAfter, time.Duration(0))
+	assert.Greater(t, breaker.RetryAfter(), time.Duration(0))
+}
+
+func TestCircuitBreaker_ProbeClosesCircuit(t *testing.T) {
+	breaker := client.NewCircuitBreaker("https://api.example.com", client.CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+	start := time.Now()
+
+	breaker.Record(false)
+	time.Sleep(20 * time.Millisecond)
+
+	require.NoError(t, breaker.Allow(), "the first request after the open timeout is the probe")
+	require.Error(t, breaker.Allow(), "only one probe at a time")
+
+	breaker.Record(true)
+
+	assert.True(t, breaker.Available())
+	require.NoError(t, breaker.Allow())
+	assert.GreaterOrEqual(t, breaker.UnavailableSince(start), 20*time.Millisecond)
+	assert.Zero(t, breaker.UnavailableSince(time.Now()))
+}
+
+func TestDoAPIRequest_CircuitBreakerStopsRequests(t *testing.T) {
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.Anything).Return(func(req *http.Request) (*http.Response, error) {
+		return statusResponse(http.StatusServiceUnavailable, ""), nil
+	}, nil)
+
+	helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+	helper.RetryPolicy = client.RetryPolicy{}
+	helper.Breakers = client.NewCircuitBreakers(client.CircuitBreakerSettings{FailureThreshold: 2, OpenTimeout: time.Minute})
+	ctx := client.WithAPIToken(context.Background(), "token")
+
+	for range 2 {
+		var apiErr *client.ApiError
+		require.ErrorAs(t, helper.DoAPIRequest(ctx, http.MethodGet, "/projects", nil, nil), &apiErr)
+	}
+
+	var circuitErr *client.CircuitOpenError
+	require.ErrorAs(t, helper.DoAPIRequest(ctx, http.MethodGet, "/projects", nil, nil), &circuitErr)
+	mockHTTPClient.AssertNumberOfCalls(t, "Do", 2)
+}
+
+func TestDoAPIRequest_ClientErrorsKeepCircuitClosed(t *testing.T) {
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.Anything).Return(func(req *http.Request) (*http.Response, error) {
+		return statusResponse(http.StatusBadRequest, ""), nil
+	}, nil)
+
+	helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+	helper.Breakers = client.NewCircuitBreakers(client.CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute})
+	ctx := client.WithAPIToken(context.Background(), "token")
+
+	for range 3 {
+		var apiErr *client.ApiError
+		require.ErrorAs(t, helper.DoAPIRequest(ctx, http.MethodGet, "/projects", nil, nil), &apiErr)
+	}
+
+	assert.True(t, helper.Breaker(ctx).Available())
+	mockHTTPClient.AssertNumberOfCalls(t, "Do", 3)
+}
+
+func TestDoAPIRequest_CircuitBreakerPerGateway(t *testing.T) {
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.MatchedBy(func(req *http.Request) bool { return req.URL.Host == "tenant.example.com" })).
+		Return(func(req *http.Request) (*http.Response, error) {
+			return statusResponse(http.StatusServiceUnavailable, ""), nil
+		}, nil)
+	mockHTTPClient.On("Do", mock.Anything).Return(func(req *http.Request) (*http.Response, error) {
+		return statusResponse(http.StatusOK, ""), nil
+	}, nil)
+
+	helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+	helper.RetryPolicy = client.RetryPolicy{}
+	helper.Breakers = client.NewCircuitBreakers(client.CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute})
+	ctx := client.WithAPIToken(context.Background(), "token")
+	tenantCtx := client.WithAPIGateway(ctx, "https://tenant.example.com")
+
+	var apiErr *client.ApiError
+	require.ErrorAs(t, helper.DoAPIRequest(tenantCtx, http.MethodGet, "/projects", nil, nil), &apiErr)
+	var circuitErr *client.CircuitOpenError
+	require.ErrorAs(t, helper.DoAPIRequest(tenantCtx, http.MethodGet, "/projects", nil, nil), &circuitErr)
+
+	// The outage of the gateway of a tenant leaves the configured one available
+	require.NoError(t, helper.DoAPIRequest(ctx, http.MethodGet, "/projects", nil, nil))
+	assert.True(t, helper.Breaker(ctx).Available())
+	assert.False(t, helper.Breaker(tenantCtx).Available())
+}
@@ -8,10 +8,16 @@ import (
 	"io"
 	"net/http"
 	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/logging"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/metrics"
 )
 
 // HTTPClient interface abstracts http.Client for testing purposes
@@ -21,12 +27,87 @@ type HTTPClient interface {
 
 // HelperClientConfig holds configuration for CMP API calls
 
-// HelperClient provides API access to CMP services
+// HelperClient provides API access to CMP services.
+// It holds no credentials: the bearer token travels with each request in the
+// context (see WithAPIToken), so concurrent reconciles for different tenants
+// can share a single HelperClient.
 type HelperClient struct {
 	client.Client
-	HTTPClient    HTTPClient
-	apiGatewayUrl string
-	apiToken      string
+	HTTPClient HTTPClient
+	// RetryPolicy controls the retries of transient failures
+	RetryPolicy RetryPolicy
+	// RateLimiter throttles the requests of each tenant, nil disables rate limiting
+	RateLimiter *TenantRateLimiter
+	// Breakers stop the requests to an API gateway while it is down, nil disables them
+	Breakers *CircuitBreakers
+	// LogBodies logs the request and response bodies, which may carry sensitive data (e.g. public keys)
+	LogBodies bool
+	// OnUnauthorized is called when the API gateway rejects the token of a request, e.g. to force a new login
+	OnUnauthorized func(ctx context.Context)
+	// apiGatewayUrl is swapped by SetAPIGateway when the operator configuration is reloaded
+	apiGatewayUrl atomic.Pointer[string]
+}
+
+// apiTokenKey is the context key under which the tenant API token is stored
+type apiTokenKey struct{}
+
+// tenantKey is the context key under which the tenant of the request is stored
+type tenantKey struct{}
+
+// apiGatewayKey is the context key under which the API gateway of the tenant is stored
+type apiGatewayKey struct{}
+
+// WithTenant returns a copy of ctx carrying the tenant the CMP calls are made for
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant stored in ctx, empty when unknown
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// WithAPIToken returns a copy of ctx carrying the API token to use for CMP calls
+func WithAPIToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, apiTokenKey{}, token)
+}
+
+// APITokenFromContext returns the API token stored in ctx, if any
+func APITokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(apiTokenKey{}).(string)
+	return token, ok && token != ""
+}
+
+// WithAPIGateway returns a copy of ctx whose CMP calls are sent to gateway instead of the configured one
+func WithAPIGateway(ctx context.Context, gateway string) context.Context {
+	return context.WithValue(ctx, apiGatewayKey{}, gateway)
+}
+
+// apiGateway returns the API gateway of the request: the one stored in ctx, or the configured one
+func (c *HelperClient) apiGateway(ctx context.Context) string {
+	if gateway, _ := ctx.Value(apiGatewayKey{}).(string); gateway != "" {
+		return strings.TrimSuffix(gateway, "/")
+	}
+	return c.defaultAPIGateway()
+}
+
+// Breaker returns the circuit breaker of the API gateway of the request
+func (c *HelperClient) Breaker(ctx context.Context) *CircuitBreaker {
+	return c.Breakers.For(c.apiGateway(ctx))
+}
+
+// defaultAPIGateway returns the configured API gateway
+func (c *HelperClient) defaultAPIGateway() string {
+	if gateway := c.apiGatewayUrl.Load(); gateway != nil {
+		return *gateway
+	}
+	return ""
+}
+
+// SetAPIGateway replaces the configured API gateway. Requests already sent keep the previous one.
+func (c *HelperClient) SetAPIGateway(gateway string) {
+	c.apiGatewayUrl.Store(&gateway)
 }
 
 type TokenResponse struct {
@@ -67,6 +148,11 @@ func (e *ApiError) IsInvalidStatus() bool {
 	return e.Status == 404 || e.Status == 400
 }
 
+// IsNotFound reports whether the remote resource does not exist
+func (e *ApiError) IsNotFound() bool {
+	return e.Status == http.StatusNotFound
+}
+
 // NewHelperClient creates a new HelperClient instance
 func NewHelperClient(k8sClient client.Client, httpClient HTTPClient, gw_uri string) *HelperClient {
 	if httpClient == nil {
@@ -75,49 +161,75 @@ func NewHelperClient(k8sClient client.Client, httpClient HTTPClient, gw_uri stri
 		httpClient = &http.Client{}
 	}
 
-	return &HelperClient{
-		Client:        k8sClient,
-		HTTPClient:    httpClient,
-		apiGatewayUrl: gw_uri,
+	c := &HelperClient{
+		Client:      k8sClient,
+		HTTPClient:  httpClient,
+		RetryPolicy: DefaultRetryPolicy(),
 	}
+	c.SetAPIGateway(gw_uri)
+	return c
 }
 
-func (c *HelperClient) SetAPIToken(token string) {
-	c.apiToken = token
+// Ping checks that the API gateway answers. No token is sent: any response below 500,
+// including 401, proves the gateway is reachable.
+func (c *HelperClient) Ping(ctx context.Context) error {
+	apiGatewayUrl := c.defaultAPIGateway()
+	if apiGatewayUrl == "" {
+		return fmt.Errorf("api gateway url not loaded")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiGatewayUrl, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("api gateway unreachable: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("api gateway unavailable: %s", resp.Status)
+	}
+	return nil
 }
 
 // DoAPIRequest performs an authenticated API request
 func (c *HelperClient) DoAPIRequest(ctx context.Context, method, endpoint string, body, response any) error {
-	if c.apiGatewayUrl == "" {
+	apiGatewayUrl := c.apiGateway(ctx)
+	if apiGatewayUrl == "" {
 		return fmt.Errorf("api gateway url not loaded")
 	}
 
-	url := fmt.Sprintf("%s%s", c.apiGatewayUrl, endpoint)
+	apiToken, ok := APITokenFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("api token not found in request context")
+	}
+
+	url := fmt.Sprintf("%s%s", apiGatewayUrl, endpoint)
 	clientLog := ctrl.Log.WithValues("Method", method, "Url", url)
 	clientLog.Info("API Request")
 
-	var reqBody io.Reader
+	var jsonData []byte
 	if body != nil {
-		clientLog.Info("API Request", "Body", fmt.Sprintf("%+v", body))
-		jsonData, err := json.Marshal(body)
+		if c.LogBodies {
+			clientLog.Info("API Request", "Body", logging.Redact(body))
+		}
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return err
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	if err := c.Breaker(ctx).Allow(); err != nil {
+		return err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "*/*")
-	req.Header.Set("Authorization", "Bearer "+c.apiToken)
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.doWithRetry(ctx, method, url, endpoint, apiToken, jsonData)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return err
 	}
 
 	clientLog.Info("API Response", "Status", resp.Status)
@@ -142,11 +254,17 @@ func (c *HelperClient) DoAPIRequest(ctx context.Context, method, endpoint string
 			if err := json.Unmarshal(responseBody, &response); err != nil {
 				return fmt.Errorf("failed to read response body: %w", err)
 			}
-			clientLog.Info("API Response", "Body", fmt.Sprintf("%+v", response))
+			if c.LogBodies {
+				clientLog.Info("API Response", "Body", logging.Redact(response))
+			}
 		}
 		return nil
 	}
 
+	if resp.StatusCode == http.StatusUnauthorized && c.OnUnauthorized != nil {
+		c.OnUnauthorized(ctx)
+	}
+
 	// For 4xx and 5xx errors, return ApiError with full response body
 	if resp.StatusCode >= 400 && resp.StatusCode < 600 {
 		var responseErr ApiError
@@ -173,3 +291,60 @@ func (c *HelperClient) DoAPIRequest(ctx context.Context, method, endpoint string
 	// For other errors, return standard error
 	return fmt.Errorf("request failed with status: %d", resp.StatusCode)
 }
+
+// doWithRetry sends the request, waiting for the rate limiter of the tenant before each attempt
+// and retrying transient failures according to the retry policy
+func (c *HelperClient) doWithRetry(ctx context.Context, method, url, endpoint, apiToken string, jsonData []byte) (*http.Response, error) {
+	clientLog := ctrl.Log.WithValues("Method", method, "Url", url)
+	tenant := TenantFromContext(ctx)
+
+	for attempt := 0; ; attempt++ {
+		if err := c.RateLimiter.Wait(ctx, tenant); err != nil {
+			return nil, fmt.Errorf("rate limiter wait: %w", err)
+		}
+
+		var reqBody io.Reader
+		if jsonData != nil {
+			reqBody = bytes.NewReader(jsonData)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "*/*")
+		req.Header.Set("Authorization", "Bearer "+apiToken)
+
+		start := time.Now()
+		resp, err := c.HTTPClient.Do(req)
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		metrics.ObserveAPIRequest(method, endpoint, statusCode, time.Since(start))
+
+		wait, retry := c.RetryPolicy.retryDelay(method, attempt, resp, err)
+		if !retry {
+			if ctx.Err() == nil {
+				c.Breaker(ctx).Record(!isGatewayFailure(resp, err))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute request: %w", err)
+			}
+			return resp, nil
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		clientLog.Info("Retrying API request", "Attempt", attempt+1, "Status", statusCode, "Error", err, "Wait", wait)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
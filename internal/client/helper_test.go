@@ -0,0 +1,166 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/metrics"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func okResponse() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+		Header:     make(http.Header),
+	}
+}
+
+func TestDoAPIRequest_UsesTokenFromContext(t *testing.T) {
+	tokens := map[string]string{
+		"tenant-a": "token-a",
+		"tenant-b": "token-b",
+	}
+
+	for tenant, token := range tokens {
+		t.Run(tenant, func(t *testing.T) {
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			mockHTTPClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+				return req.Header.Get("Authorization") == "Bearer "+token
+			})).Return(okResponse(), nil)
+
+			helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+			ctx := client.WithAPIToken(context.Background(), token)
+
+			err := helper.DoAPIRequest(ctx, http.MethodGet, "/projects", nil, nil)
+
+			require.NoError(t, err)
+			mockHTTPClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDoAPIRequest_MissingToken(t *testing.T) {
+	mockHTTPClient := new(mocks.MockHTTPClient)
+
+	helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+
+	err := helper.DoAPIRequest(context.Background(), http.MethodGet, "/projects", nil, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api token")
+	mockHTTPClient.AssertNotCalled(t, "Do", mock.Anything)
+}
+
+func TestDoAPIRequest_RecordsMetrics(t *testing.T) {
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.Anything).Return(&http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       io.NopCloser(strings.NewReader(`{"title":"unavailable"}`)),
+		Header:     make(http.Header),
+	}, nil)
+
+	helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+	helper.RetryPolicy = client.RetryPolicy{}
+	ctx := client.WithAPIToken(context.Background(), "token")
+	requests := metrics.APIRequests.WithLabelValues(http.MethodGet, "Aruba.Network/vpcs/{id}", "503")
+	before := testutil.ToFloat64(requests)
+
+	err := helper.DoAPIRequest(ctx, http.MethodGet, "/projects/p1/providers/Aruba.Network/vpcs/v1", nil, nil)
+
+	require.Error(t, err)
+	assert.Equal(t, before+1, testutil.ToFloat64(requests))
+}
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		expectedErr bool
+	}{
+		{name: "reachable", status: http.StatusOK},
+		{name: "unauthenticated", status: http.StatusUnauthorized},
+		{name: "unavailable", status: http.StatusServiceUnavailable, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := okResponse()
+			resp.StatusCode = tt.status
+			resp.Status = http.StatusText(tt.status)
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			mockHTTPClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+				return req.Header.Get("Authorization") == ""
+			})).Return(resp, nil)
+
+			helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+
+			err := helper.Ping(context.Background())
+
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mockHTTPClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDoAPIRequest_UsesGatewayFromContext(t *testing.T) {
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.String() == "https://tenant.example.com/projects"
+	})).Return(okResponse(), nil)
+
+	helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+	ctx := client.WithAPIGateway(client.WithAPIToken(context.Background(), "token"), "https://tenant.example.com/")
+
+	err := helper.DoAPIRequest(ctx, http.MethodGet, "/projects", nil, nil)
+
+	require.NoError(t, err)
+	mockHTTPClient.AssertExpectations(t)
+}
+
+func TestDoAPIRequest_UsesReconfiguredGateway(t *testing.T) {
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.String() == "https://api2.example.com/projects"
+	})).Return(okResponse(), nil)
+
+	helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+	helper.SetAPIGateway("https://api2.example.com")
+
+	err := helper.DoAPIRequest(client.WithAPIToken(context.Background(), "token"), http.MethodGet, "/projects", nil, nil)
+
+	require.NoError(t, err)
+	mockHTTPClient.AssertExpectations(t)
+}
+
+func TestDoAPIRequest_Unauthorized(t *testing.T) {
+	resp := okResponse()
+	resp.StatusCode = http.StatusUnauthorized
+	resp.Status = http.StatusText(http.StatusUnauthorized)
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.Anything).Return(resp, nil)
+
+	var rejectedTenant string
+	helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+	helper.OnUnauthorized = func(ctx context.Context) {
+		rejectedTenant = client.TenantFromContext(ctx)
+	}
+	ctx := client.WithTenant(client.WithAPIToken(context.Background(), "token"), "tenant-a")
+
+	err := helper.DoAPIRequest(ctx, http.MethodGet, "/projects", nil, nil)
+
+	require.Error(t, err)
+	assert.Equal(t, "tenant-a", rejectedTenant)
+}
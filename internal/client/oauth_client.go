@@ -2,11 +2,19 @@ package client
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/Nerzal/gocloak/v13"
+	"golang.org/x/sync/singleflight"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/metrics"
 )
 
 type IOauth interface {
@@ -15,6 +23,8 @@ type IOauth interface {
 
 type IOauthClient interface {
 	LoginClient(ctx context.Context, clientID, clientSecret, realm string, options ...string) (*gocloak.JWT, error)
+	RefreshToken(ctx context.Context, refreshToken, clientID, clientSecret, realm string) (*gocloak.JWT, error)
+	GetIssuer(ctx context.Context, realm string) (*gocloak.IssuerResponse, error)
 }
 
 type OauthClient struct {
@@ -29,100 +39,358 @@ func (k *OauthClient) LoginClient(ctx context.Context, clientID, clientSecret, r
 	return k.cli.LoginClient(ctx, clientID, clientSecret, realm, options...)
 }
 
+func (k *OauthClient) RefreshToken(ctx context.Context, refreshToken, clientID, clientSecret, realm string) (*gocloak.JWT, error) {
+	return k.cli.RefreshToken(ctx, refreshToken, clientID, clientSecret, realm)
+}
+
+func (k *OauthClient) GetIssuer(ctx context.Context, realm string) (*gocloak.IssuerResponse, error) {
+	return k.cli.GetIssuer(ctx, realm)
+}
+
+// Credentials are the Keycloak client credentials of a tenant
+type Credentials struct {
+	ClientID     string
+	ClientSecret string
+	// KeycloakURL overrides the Keycloak URL of the TokenManager, when set
+	KeycloakURL string
+}
+
+// CredentialsFunc looks up the credentials of a tenant, e.g. from Vault
+type CredentialsFunc func(ctx context.Context, tenant string) (Credentials, error)
+
+// credentialsRejectedKey is the context key marking the lookups made after Keycloak rejected the credentials
+type credentialsRejectedKey struct{}
+
+// CredentialsRejected reports whether a CredentialsFunc is called because Keycloak rejected the
+// cached credentials of the tenant, in which case it must not return a cached copy of them
+func CredentialsRejected(ctx context.Context) bool {
+	rejected, _ := ctx.Value(credentialsRejectedKey{}).(bool)
+	return rejected
+}
+
+// isUnauthorized reports whether Keycloak rejected the client credentials
+func isUnauthorized(err error) bool {
+	var apiErr *gocloak.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusUnauthorized
+}
+
+const (
+	// expiryMargin is how long before its expiry a token is no longer used
+	expiryMargin = 10 * time.Second
+	// refreshInterval is how often the tokens about to expire are refreshed in the background
+	refreshInterval = 15 * time.Second
+	// minRefreshBefore is the least time before its expiry a token is refreshed in the background
+	minRefreshBefore = 30 * time.Second
+	// minIdleTimeout is the least time an unused token is kept, longer ones are kept for their lifetime
+	minIdleTimeout = 5 * time.Minute
+	// defaultTenantKey is the cache key of the static configuration, not a valid tenant name
+	defaultTenantKey = "<default>"
+)
+
 type TokenManager struct {
 	client IOauthClient
-	ctx    context.Context
-	cache  *TokenCache
+	// newClient creates the clients of the Keycloak URLs overridden by the credentials
+	newClient func(baseURL string) IOauthClient
+	clients   map[string]IOauthClient
+	cache     *TokenCache
+	// group deduplicates the concurrent token requests of a tenant
+	group singleflight.Group
 
-	mu           sync.Mutex
-	clientID     string
-	clientSecret string
-	realm        string
-	baseURL      string
+	mu sync.Mutex
+	// defaults are the credentials of the tenants without their own, i.e. the static configuration
+	defaults Credentials
+	realm    string
+	baseURL  string
 }
 
 type ITokenManager interface {
-	GetAccessToken(checkCache bool, tenant string) (string, error)
+	GetAccessToken(ctx context.Context, checkCache bool, tenant string) (string, error)
+	GetTenantToken(ctx context.Context, tenant string, lookup CredentialsFunc) (string, error)
 	GetActiveToken(tenant string) string
-	SetClientIdAndSecret(clientID string, clientSecret string)
+	Invalidate(tenant string)
+	ExpireToken(tenant string)
+	Reconfigure(baseURL, realm string, defaults Credentials)
+	Start(ctx context.Context) error
 	IsExpiredHelper(cToken *CachedToken) bool
+	Ping(ctx context.Context) error
 }
 
+// TokenCache holds the credentials and the last token of each tenant
 type TokenCache struct {
 	mu     sync.RWMutex
 	tokens map[string]*CachedToken
 }
 type CachedToken struct {
-	token     *gocloak.JWT
-	retrieved time.Time
+	credentials Credentials
+	token       *gocloak.JWT
+	retrieved   time.Time
+	// expires is when the access token expires, refreshExpires when the refresh token does
+	// (zero without refresh token)
+	expires        time.Time
+	refreshExpires time.Time
+	// lastUsed is when the token was last requested, the tokens left unused are not refreshed
+	lastUsed time.Time
+}
+
+// newCachedToken returns the cache entry of a token retrieved at the given time. The expiry
+// is read from the exp claim of the JWT, falling back to expires_in for opaque tokens.
+func newCachedToken(credentials Credentials, token *gocloak.JWT, retrieved time.Time) *CachedToken {
+	cached := &CachedToken{
+		credentials: credentials,
+		token:       token,
+		retrieved:   retrieved,
+		lastUsed:    retrieved,
+	}
+	var ok bool
+	if cached.expires, ok = jwtExpiry(token.AccessToken); !ok {
+		cached.expires = retrieved.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	if token.RefreshToken != "" {
+		if cached.refreshExpires, ok = jwtExpiry(token.RefreshToken); !ok {
+			cached.refreshExpires = retrieved.Add(time.Duration(token.RefreshExpiresIn) * time.Second)
+		}
+	}
+	return cached
+}
+
+// jwtExpiry returns the exp claim of a JWT, false when token is not a JWT or has no exp claim.
+// The signature is not verified: the token is only read to know when to renew it.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(claims.Exp), 0), true
+}
+
+// cacheKey returns the cache key of a tenant, tokens of the static configuration have no tenant
+func cacheKey(tenant string) string {
+	if tenant == "" {
+		return defaultTenantKey
+	}
+	return tenant
 }
 
 func (c *TokenCache) get(tenant string) *CachedToken {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	if tenant == "" {
-		return c.tokens["public"]
+	return c.tokens[cacheKey(tenant)]
+}
+
+// use returns the cached token of tenant and records that it was requested
+func (c *TokenCache) use(tenant string) *CachedToken {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached := c.tokens[cacheKey(tenant)]
+	if cached != nil {
+		cached.lastUsed = time.Now()
 	}
-	return c.tokens[tenant]
+	return cached
 }
 
-func (c *TokenCache) set(tenant string, token *gocloak.JWT) {
+// set caches a new token of tenant, keeping when the previous one was last requested
+func (c *TokenCache) set(tenant string, credentials Credentials, token *gocloak.JWT) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if tenant == "" {
-		c.tokens["public"] = &CachedToken{
-			token:     token,
-			retrieved: time.Now(),
+	cached := newCachedToken(credentials, token, time.Now())
+	if previous, ok := c.tokens[cacheKey(tenant)]; ok {
+		cached.lastUsed = previous.lastUsed
+	}
+	c.tokens[cacheKey(tenant)] = cached
+}
+
+func (c *TokenCache) delete(tenant string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.tokens, cacheKey(tenant))
+}
+
+// expire drops the token of the tenant but keeps its credentials, so a new login is made with them
+func (c *TokenCache) expire(tenant string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.tokens[cacheKey(tenant)]; ok {
+		c.tokens[cacheKey(tenant)] = &CachedToken{
+			credentials: cached.credentials,
+			token:       &gocloak.JWT{},
+			retrieved:   cached.retrieved,
+			lastUsed:    cached.lastUsed,
+		}
+	}
+}
+
+// expiring returns the keys of the tokens that expire within max(lifetime/5, minRefreshBefore)
+// and can be renewed with their cached credentials. Idle tokens are left to expire.
+func (c *TokenCache) expiring(now time.Time) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var keys []string
+	for key, cached := range c.tokens {
+		if cached.token.AccessToken == "" || cached.credentials.ClientID == "" || cached.idle(now) {
+			continue
+		}
+		refreshBefore := max(cached.expires.Sub(cached.retrieved)/5, minRefreshBefore)
+		if now.After(cached.expires.Add(-refreshBefore)) {
+			keys = append(keys, key)
 		}
-		return
 	}
+	return keys
+}
 
-	c.tokens[tenant] = &CachedToken{
-		token:     token,
-		retrieved: time.Now(),
+// evictIdle drops the tokens and the credentials of the tenants that no longer request them
+func (c *TokenCache) evictIdle(now time.Time) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var keys []string
+	for key, cached := range c.tokens {
+		if cached.idle(now) {
+			delete(c.tokens, key)
+			keys = append(keys, key)
+		}
 	}
+	return keys
 }
 
-// NewTokenManager creates a new Keycloak client credentials manager.
+// idle reports whether the token was not requested within its lifetime, or minIdleTimeout
+// when shorter
+func (c *CachedToken) idle(now time.Time) bool {
+	return now.Sub(c.lastUsed) > max(c.expires.Sub(c.retrieved), minIdleTimeout)
+}
+
+func (c *TokenCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.tokens)
+}
+
+// NewTokenManager creates a new Keycloak client credentials manager. clientID and clientSecret
+// are the default credentials, used by the tenants whose credentials are not looked up.
 func NewTokenManager(baseURL, realm, clientID, clientSecret string, keycloak IOauth) ITokenManager {
-	var cli IOauthClient
+	newClient := func(url string) IOauthClient {
+		return gocloak.NewClient(url)
+	}
 	if keycloak != nil {
-		cli = keycloak.NewClient(baseURL)
-	} else {
-		cli = gocloak.NewClient(baseURL)
+		newClient = keycloak.NewClient
 	}
 	return &TokenManager{
-		client:       cli,
-		ctx:          context.Background(),
-		cache:        &TokenCache{tokens: make(map[string]*CachedToken)},
-		clientID:     clientID,
-		clientSecret: clientSecret,
-		realm:        realm,
-		baseURL:      baseURL,
+		client:    newClient(baseURL),
+		newClient: newClient,
+		clients:   make(map[string]IOauthClient),
+		cache:     &TokenCache{tokens: make(map[string]*CachedToken)},
+		defaults:  Credentials{ClientID: clientID, ClientSecret: clientSecret},
+		realm:     realm,
+		baseURL:   baseURL,
 	}
 }
 
-func (tm *TokenManager) SetClientIdAndSecret(clientID string, clientSecret string) {
-	tm.mu.Lock()
-	defer tm.mu.Unlock()
+// Start refreshes the cached tokens before they expire, until ctx is done, so that reconciles
+// rarely wait for Keycloak. It is a manager.Runnable.
+func (tm *TokenManager) Start(ctx context.Context) error {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			tm.refreshExpiring(ctx, time.Now())
+		}
+	}
+}
+
+// refreshExpiring renews the tokens about to expire with their cached credentials and drops
+// the idle ones. A failure keeps the current token: it is renewed again when requested.
+func (tm *TokenManager) refreshExpiring(ctx context.Context, now time.Time) {
+	for _, key := range tm.cache.evictIdle(now) {
+		ctrl.Log.V(1).Info("Evicting idle token", "tenant", key)
+	}
+	for _, key := range tm.cache.expiring(now) {
+		_, err, _ := tm.group.Do(key, func() (any, error) {
+			cached := tm.cache.get(key)
+			if cached == nil || cached.token.AccessToken == "" {
+				return nil, nil
+			}
+			tk, err := tm.renew(ctx, cached)
+			if err != nil {
+				return nil, err
+			}
+			tm.cache.set(key, cached.credentials, tk)
+			return nil, nil
+		})
+		if err != nil {
+			ctrl.Log.V(1).Info("Background token refresh failed", "tenant", key, "error", err)
+		}
+	}
+}
+
+// renew returns a new token for the cached one: through its refresh token while valid,
+// otherwise through a new login with the cached credentials
+func (tm *TokenManager) renew(ctx context.Context, cached *CachedToken) (*gocloak.JWT, error) {
+	if cached.token.RefreshToken != "" && time.Now().Before(cached.refreshExpires.Add(-expiryMargin)) {
+		tk, err := tm.refreshToken(ctx, cached)
+		if err == nil {
+			return tk, nil
+		}
+		ctrl.Log.V(1).Info("Token refresh failed, logging in again", "clientId", cached.credentials.ClientID, "error", err)
+	}
+	return tm.getToken(ctx, cached.credentials)
+}
 
-	tm.clientID = clientID
-	tm.clientSecret = clientSecret
+// refreshToken retrieves a new token using the refresh token of the cached one
+func (tm *TokenManager) refreshToken(ctx context.Context, cached *CachedToken) (*gocloak.JWT, error) {
+	cli := tm.clientFor(cached.credentials.KeycloakURL)
+	tm.mu.Lock()
+	realm := tm.realm
+	tm.mu.Unlock()
+	ctrl.Log.V(1).Info("Refreshing token", "clientId", cached.credentials.ClientID, "realm", realm)
+	token, err := cli.RefreshToken(ctx, cached.token.RefreshToken, cached.credentials.ClientID, cached.credentials.ClientSecret, realm)
+	metrics.TokenRefreshes.WithLabelValues(metrics.ResultLabel(err)).Inc()
+	return token, err
 }
 
 // getToken retrieves a new token using client credentials.
-func (tm *TokenManager) getToken() (*gocloak.JWT, error) {
-	ctrl.Log.V(1).Info("Getting token with client credentials", "clientId", tm.clientID, "realm", tm.realm)
-	token, err := tm.client.LoginClient(tm.ctx, tm.clientID, tm.clientSecret, tm.realm)
+func (tm *TokenManager) getToken(ctx context.Context, credentials Credentials) (*gocloak.JWT, error) {
+	cli := tm.clientFor(credentials.KeycloakURL)
+	tm.mu.Lock()
+	realm := tm.realm
+	tm.mu.Unlock()
+	ctrl.Log.V(1).Info("Getting token with client credentials", "clientId", credentials.ClientID, "realm", realm)
+	token, err := cli.LoginClient(ctx, credentials.ClientID, credentials.ClientSecret, realm)
+	metrics.TokenFetches.WithLabelValues(metrics.ResultLabel(err)).Inc()
 	if err != nil {
 		return nil, err
 	}
 	return token, nil
 }
 
+// clientFor returns the Keycloak client of baseURL, the default one when empty
+func (tm *TokenManager) clientFor(baseURL string) IOauthClient {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if baseURL == "" || baseURL == tm.baseURL {
+		return tm.client
+	}
+	cli, ok := tm.clients[baseURL]
+	if !ok {
+		cli = tm.newClient(baseURL)
+		tm.clients[baseURL] = cli
+	}
+	return cli
+}
+
 func (tm *TokenManager) GetActiveToken(tenant string) string {
 	ctrl.Log.V(1).Info("GetActiveToken", "tenant", tenant)
-	token := tm.cache.get(tenant)
+	token := tm.cache.use(tenant)
 	// If we have a valid cached token
 	if token != nil && !tm.isExpired(token) {
 		ctrl.Log.V(1).Info("Found active token", "tenant", tenant)
@@ -131,45 +399,131 @@ func (tm *TokenManager) GetActiveToken(tenant string) string {
 	return ""
 }
 
-// GetAccessToken returns a valid access token, refreshing it if expired.
-func (tm *TokenManager) GetAccessToken(checkCache bool, tenant string) (string, error) {
+// GetAccessToken returns a valid access token obtained with the default credentials, refreshing it if expired.
+func (tm *TokenManager) GetAccessToken(ctx context.Context, checkCache bool, tenant string) (string, error) {
+	ctrl.Log.V(1).Info("GetAccessToken, if checkCache is enabled search it on cache before", "checkCache", checkCache, "tenant", tenant)
+	if checkCache {
+		if token := tm.GetActiveToken(tenant); token != "" {
+			return token, nil
+		}
+	}
+	return tm.fetch(ctx, tenant, func(context.Context, string) (Credentials, error) {
+		tm.mu.Lock()
+		defer tm.mu.Unlock()
+		return tm.defaults, nil
+	})
+}
+
+// GetTenantToken returns a valid access token of the tenant, refreshing it if expired.
+// The credentials are looked up once and cached with the token; when the cached credentials
+// are rejected they are looked up again, in case they were rotated.
+func (tm *TokenManager) GetTenantToken(ctx context.Context, tenant string, lookup CredentialsFunc) (string, error) {
+	if token := tm.GetActiveToken(tenant); token != "" {
+		return token, nil
+	}
+	return tm.fetch(ctx, tenant, lookup)
+}
+
+// Invalidate drops the credentials and the token of the tenant, so they are looked up again
+func (tm *TokenManager) Invalidate(tenant string) {
+	ctrl.Log.V(1).Info("Invalidating token", "tenant", tenant)
+	tm.cache.delete(tenant)
+}
+
+// ExpireToken drops the token of the tenant but keeps its credentials, forcing a new login
+// at the next request, e.g. after the API gateway rejected the token
+func (tm *TokenManager) ExpireToken(tenant string) {
+	ctrl.Log.V(1).Info("Expiring token", "tenant", tenant)
+	tm.cache.expire(tenant)
+}
+
+// Reconfigure replaces the Keycloak URL, the realm and the default credentials. When any of
+// them changes every cached token is dropped, as it was issued for the previous configuration.
+func (tm *TokenManager) Reconfigure(baseURL, realm string, defaults Credentials) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
+	if baseURL == tm.baseURL && realm == tm.realm && defaults == tm.defaults {
+		return
+	}
+	if baseURL != tm.baseURL {
+		tm.client = tm.newClient(baseURL)
+		tm.baseURL = baseURL
+	}
+	tm.realm = realm
+	tm.defaults = defaults
+	tm.cache.clear()
+	ctrl.Log.Info("Keycloak configuration changed, cached tokens dropped", "keycloakUrl", baseURL, "realm", realm)
+}
 
-	ctrl.Log.V(1).Info("GetAccessToken, if checkCache is enabled search it on cache before", "checkCache", checkCache, "tenant", tenant)
-	if checkCache {
-		token := tm.cache.get(tenant)
+// fetch requests a token for the tenant and caches it with its credentials. Concurrent
+// requests of the same tenant share a single login, so a token is never stored under
+// the key of another tenant. The shared login runs with the context of the first request.
+func (tm *TokenManager) fetch(ctx context.Context, tenant string, lookup CredentialsFunc) (string, error) {
+	token, err, _ := tm.group.Do(cacheKey(tenant), func() (any, error) {
+		var rejected *Credentials
+		var rejectedErr error
+		if cached := tm.cache.get(tenant); cached != nil && cached.credentials.ClientID != "" {
+			tk, err := tm.renew(ctx, cached)
+			if err == nil {
+				tm.cache.set(tenant, cached.credentials, tk)
+				return tk.AccessToken, nil
+			}
+			ctrl.Log.V(1).Info("Cached credentials rejected, looking them up again", "tenant", tenant)
+			rejected, rejectedErr = &cached.credentials, err
+			if isUnauthorized(err) {
+				ctx = context.WithValue(ctx, credentialsRejectedKey{}, true)
+			}
+		}
 
-		// If we have a valid cached token
-		if token != nil && !tm.isExpired(token) {
-			ctrl.Log.V(1).Info("found token in cache", "tenant", tenant)
-			return token.token.AccessToken, nil
+		credentials, err := lookup(ctx, tenant)
+		if err != nil {
+			return "", err
 		}
-	}
-	// If expired or missing, renew
-	tk, err := tm.getToken()
+		var tk *gocloak.JWT
+		if rejected != nil && *rejected == credentials {
+			err = rejectedErr
+		} else {
+			tk, err = tm.getToken(ctx, credentials)
+		}
+		if err != nil {
+			tm.cache.delete(tenant)
+			return "", err
+		}
+		ctrl.Log.V(1).Info("Set Token in memory cache", "tenant", tenant)
+		tm.cache.set(tenant, credentials, tk)
+		return tk.AccessToken, nil
+	})
 	if err != nil {
 		return "", err
 	}
+	return token.(string), nil
+}
 
-	ctrl.Log.V(1).Info("Set Token in memory cache", "tenant", tenant)
-	tm.cache.set(tenant, tk)
-	return tk.AccessToken, nil
+// Ping requests the public description of the realm, confirming that Keycloak is reachable
+// without depending on the credentials of any tenant
+func (tm *TokenManager) Ping(ctx context.Context) error {
+	tm.mu.Lock()
+	cli, realm := tm.client, tm.realm
+	tm.mu.Unlock()
+	_, err := cli.GetIssuer(ctx, realm)
+	return err
 }
 
 // isExpired checks if the token is expired (with 10s safety margin)
 func (tm *TokenManager) isExpired(cToken *CachedToken) bool {
-	const safetyMargin = 10 * time.Second
-	ctrl.Log.V(1).Info("Checking expired token", "token", cToken.token.AccessToken)
-	expiration := cToken.retrieved.Add(time.Duration(cToken.token.ExpiresIn) * time.Second)
-	return time.Now().After(expiration.Add(-safetyMargin))
+	return time.Now().After(cToken.expires.Add(-expiryMargin))
 }
 
 func SetCachedTokenHelper(token *gocloak.JWT, retrieved time.Time) *CachedToken {
-	return &CachedToken{
-		token:     token,
-		retrieved: retrieved,
-	}
+	return newCachedToken(Credentials{}, token, retrieved)
+}
+
+func (tm *TokenManager) RefreshExpiringHelper(ctx context.Context) {
+	tm.refreshExpiring(ctx, time.Now())
+}
+
+func (tm *TokenManager) RefreshExpiringAtHelper(ctx context.Context, now time.Time) {
+	tm.refreshExpiring(ctx, now)
 }
 
 func (tm *TokenManager) IsExpiredHelper(cToken *CachedToken) bool {
@@ -1,7 +1,13 @@
 package client_test
 
 import (
+	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,7 +32,7 @@ func TestOauthLogin(t *testing.T) {
 
 	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "client-id", "client-secret", mockOauth)
 
-	jwt, err := tm.GetAccessToken(true, "tenant")
+	jwt, err := tm.GetAccessToken(t.Context(), true, "tenant")
 
 	require.NoError(t, err)
 	assert.Equal(t, "access-token", jwt)
@@ -86,7 +92,7 @@ func TestTokenManager_GetAccessToken(t *testing.T) {
 				mockKeycloak,
 			)
 
-			token, err := tm.GetAccessToken(true, "tenant")
+			token, err := tm.GetAccessToken(t.Context(), true, "tenant")
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -120,3 +126,338 @@ func TestIsExpired(t *testing.T) {
 
 	assert.True(t, tm.IsExpiredHelper(tokenExpired), "Token should be expired")
 }
+
+func TestTokenManager_GetTenantToken(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	mockOauthClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", mock.Anything).Return(mockOauthClient)
+	mockOauthClient.On("LoginClient", mock.Anything, "id-a", "secret-a", "realm").
+		Return(&gocloak.JWT{AccessToken: "token-a", ExpiresIn: 300}, nil).Once()
+	mockOauthClient.On("LoginClient", mock.Anything, "id-b", "secret-b", "realm").
+		Return(&gocloak.JWT{AccessToken: "token-b", ExpiresIn: 300}, nil).Once()
+
+	credentials := map[string]client.Credentials{
+		"tenant-a": {ClientID: "id-a", ClientSecret: "secret-a"},
+		"tenant-b": {ClientID: "id-b", ClientSecret: "secret-b"},
+	}
+	var lookups atomic.Int32
+	lookup := func(_ context.Context, tenant string) (client.Credentials, error) {
+		lookups.Add(1)
+		return credentials[tenant], nil
+	}
+
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "", "", mockOauth)
+
+	// Concurrent requests of each tenant share one login and get the token of their tenant
+	var wg sync.WaitGroup
+	for range 10 {
+		for tenant, expected := range map[string]string{"tenant-a": "token-a", "tenant-b": "token-b"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				token, err := tm.GetTenantToken(t.Context(), tenant, lookup)
+				assert.NoError(t, err)
+				assert.Equal(t, expected, token)
+			}()
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), lookups.Load())
+	mockOauthClient.AssertExpectations(t)
+}
+
+func TestTokenManager_GetTenantToken_LookupError(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	mockOauthClient := new(mocks.MockIOauthClient)
+	mockOauth.On("NewClient", mock.Anything).Return(mockOauthClient)
+
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "", "", mockOauth)
+
+	_, err := tm.GetTenantToken(t.Context(), "tenant", func(context.Context, string) (client.Credentials, error) {
+		return client.Credentials{}, errors.New("vault unavailable")
+	})
+
+	require.Error(t, err)
+	mockOauthClient.AssertNotCalled(t, "LoginClient", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTokenManager_Invalidate(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	mockOauthClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", mock.Anything).Return(mockOauthClient)
+	mockOauthClient.On("LoginClient", mock.Anything, "client-id", "client-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "access-token", ExpiresIn: 300}, nil).Twice()
+
+	lookup := func(context.Context, string) (client.Credentials, error) {
+		return client.Credentials{ClientID: "client-id", ClientSecret: "client-secret"}, nil
+	}
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "", "", mockOauth)
+
+	_, err := tm.GetTenantToken(t.Context(), "tenant", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", tm.GetActiveToken("tenant"))
+
+	tm.Invalidate("tenant")
+	assert.Empty(t, tm.GetActiveToken("tenant"))
+
+	_, err = tm.GetTenantToken(t.Context(), "tenant", lookup)
+	require.NoError(t, err)
+	mockOauthClient.AssertExpectations(t)
+}
+
+func TestTokenManager_GetTenantToken_KeycloakOverride(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	defaultClient := new(mocks.MockIOauthClient)
+	tenantClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", "http://keycloak.example.com").Return(defaultClient)
+	mockOauth.On("NewClient", "http://tenant.example.com").Return(tenantClient).Once()
+	tenantClient.On("LoginClient", mock.Anything, "client-id", "client-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "tenant-token", ExpiresIn: 300}, nil)
+
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "", "", mockOauth)
+
+	token, err := tm.GetTenantToken(t.Context(), "tenant", func(context.Context, string) (client.Credentials, error) {
+		return client.Credentials{ClientID: "client-id", ClientSecret: "client-secret", KeycloakURL: "http://tenant.example.com"}, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-token", token)
+	defaultClient.AssertNotCalled(t, "LoginClient", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockOauth.AssertExpectations(t)
+}
+
+func TestTokenManager_Reconfigure(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	oldClient := new(mocks.MockIOauthClient)
+	newClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", "http://keycloak.example.com").Return(oldClient).Once()
+	mockOauth.On("NewClient", "http://keycloak2.example.com").Return(newClient).Once()
+	oldClient.On("LoginClient", mock.Anything, "client-id", "client-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "old-token", ExpiresIn: 300}, nil).Once()
+	newClient.On("LoginClient", mock.Anything, "client-id", "rotated-secret", "realm2").
+		Return(&gocloak.JWT{AccessToken: "new-token", ExpiresIn: 300}, nil).Once()
+
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "client-id", "client-secret", mockOauth)
+
+	token, err := tm.GetAccessToken(t.Context(), true, "")
+	require.NoError(t, err)
+	assert.Equal(t, "old-token", token)
+
+	// Unchanged settings keep the cached token
+	tm.Reconfigure("http://keycloak.example.com", "realm", client.Credentials{ClientID: "client-id", ClientSecret: "client-secret"})
+	assert.Equal(t, "old-token", tm.GetActiveToken(""))
+
+	tm.Reconfigure("http://keycloak2.example.com", "realm2", client.Credentials{ClientID: "client-id", ClientSecret: "rotated-secret"})
+	assert.Empty(t, tm.GetActiveToken(""))
+
+	token, err = tm.GetAccessToken(t.Context(), true, "")
+	require.NoError(t, err)
+	assert.Equal(t, "new-token", token)
+	mockOauth.AssertExpectations(t)
+	oldClient.AssertExpectations(t)
+	newClient.AssertExpectations(t)
+}
+
+func TestTokenManager_GetTenantToken_CredentialsRejected(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	mockOauthClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", mock.Anything).Return(mockOauthClient)
+	mockOauthClient.On("LoginClient", mock.Anything, "client-id", "old-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "old-token", ExpiresIn: 0}, nil).Once()
+	mockOauthClient.On("LoginClient", mock.Anything, "client-id", "old-secret", "realm").
+		Return(nil, &gocloak.APIError{Code: http.StatusUnauthorized, Message: "401 Unauthorized: invalid_client"}).Once()
+	mockOauthClient.On("LoginClient", mock.Anything, "client-id", "new-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "new-token", ExpiresIn: 300}, nil).Once()
+
+	var rejected []bool
+	secret := "old-secret"
+	lookup := func(ctx context.Context, _ string) (client.Credentials, error) {
+		rejected = append(rejected, client.CredentialsRejected(ctx))
+		return client.Credentials{ClientID: "client-id", ClientSecret: secret}, nil
+	}
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "", "", mockOauth)
+
+	_, err := tm.GetTenantToken(t.Context(), "tenant", lookup)
+	require.NoError(t, err)
+
+	// The token is expired, the cached credentials were rotated meanwhile
+	secret = "new-secret"
+	token, err := tm.GetTenantToken(t.Context(), "tenant", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "new-token", token)
+	assert.Equal(t, []bool{false, true}, rejected)
+	mockOauthClient.AssertExpectations(t)
+}
+
+// testJWT returns an unsigned JWT expiring at exp
+func testJWT(exp time.Time) string {
+	encode := func(v string) string { return base64.RawURLEncoding.EncodeToString([]byte(v)) }
+	return encode(`{"alg":"none"}`) + "." + encode(fmt.Sprintf(`{"exp":%d}`, exp.Unix())) + ".signature"
+}
+
+func TestIsExpired_JWT(t *testing.T) {
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "client-id", "client-secret", nil)
+
+	// The exp claim wins over expires_in
+	tokenValid := client.SetCachedTokenHelper(
+		&gocloak.JWT{AccessToken: testJWT(time.Now().Add(5 * time.Minute)), ExpiresIn: 0},
+		time.Now())
+	assert.False(t, tm.IsExpiredHelper(tokenValid), "Token should not be expired")
+
+	tokenExpired := client.SetCachedTokenHelper(
+		&gocloak.JWT{AccessToken: testJWT(time.Now().Add(-time.Minute)), ExpiresIn: 300},
+		time.Now())
+	assert.True(t, tm.IsExpiredHelper(tokenExpired), "Token should be expired")
+}
+
+func TestTokenManager_RefreshToken(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	mockOauthClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", mock.Anything).Return(mockOauthClient)
+	mockOauthClient.On("LoginClient", mock.Anything, "client-id", "client-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "access-token", ExpiresIn: 0, RefreshToken: "refresh-token", RefreshExpiresIn: 1800}, nil).Once()
+	mockOauthClient.On("RefreshToken", mock.Anything, "refresh-token", "client-id", "client-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "refreshed-token", ExpiresIn: 300}, nil).Once()
+
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "client-id", "client-secret", mockOauth)
+
+	token, err := tm.GetAccessToken(t.Context(), true, "tenant")
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", token)
+
+	// The access token is expired, the refresh token is still valid
+	token, err = tm.GetAccessToken(t.Context(), true, "tenant")
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed-token", token)
+	mockOauthClient.AssertExpectations(t)
+}
+
+func TestTokenManager_RefreshExpiring(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	mockOauthClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", mock.Anything).Return(mockOauthClient)
+	mockOauthClient.On("LoginClient", mock.Anything, "client-id", "client-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "about-to-expire", ExpiresIn: 20}, nil).Once()
+	mockOauthClient.On("LoginClient", mock.Anything, "client-id", "client-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "renewed-token", ExpiresIn: 300}, nil).Once()
+
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "client-id", "client-secret", mockOauth)
+
+	_, err := tm.GetAccessToken(t.Context(), true, "tenant")
+	require.NoError(t, err)
+	assert.Equal(t, "about-to-expire", tm.GetActiveToken("tenant"))
+
+	tm.(*client.TokenManager).RefreshExpiringHelper(t.Context())
+	assert.Equal(t, "renewed-token", tm.GetActiveToken("tenant"))
+
+	// A fresh token is left alone
+	tm.(*client.TokenManager).RefreshExpiringHelper(t.Context())
+	mockOauthClient.AssertExpectations(t)
+}
+
+func TestTokenManager_RefreshExpiring_EvictsIdle(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	mockOauthClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", mock.Anything).Return(mockOauthClient)
+	mockOauthClient.On("LoginClient", mock.Anything, "client-id", "client-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "access-token", ExpiresIn: 300}, nil).Twice()
+
+	lookups := 0
+	lookup := func(context.Context, string) (client.Credentials, error) {
+		lookups++
+		return client.Credentials{ClientID: "client-id", ClientSecret: "client-secret"}, nil
+	}
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "", "", mockOauth)
+
+	_, err := tm.GetTenantToken(t.Context(), "tenant", lookup)
+	require.NoError(t, err)
+
+	// A token about to expire but unused for its lifetime is dropped, not refreshed
+	tm.(*client.TokenManager).RefreshExpiringAtHelper(t.Context(), time.Now().Add(6*time.Minute))
+	assert.Empty(t, tm.GetActiveToken("tenant"))
+
+	_, err = tm.GetTenantToken(t.Context(), "tenant", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, 2, lookups, "the credentials of an evicted tenant are looked up again")
+	mockOauthClient.AssertExpectations(t)
+}
+
+func TestTokenManager_DefaultCredentialsKey(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	mockOauthClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", mock.Anything).Return(mockOauthClient)
+	mockOauthClient.On("LoginClient", mock.Anything, "client-id", "client-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "default-token", ExpiresIn: 300}, nil).Once()
+	mockOauthClient.On("LoginClient", mock.Anything, "public-id", "public-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "public-token", ExpiresIn: 300}, nil).Once()
+
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "client-id", "client-secret", mockOauth)
+
+	_, err := tm.GetAccessToken(t.Context(), true, "")
+	require.NoError(t, err)
+
+	// A tenant named public does not share the token of the static configuration
+	token, err := tm.GetTenantToken(t.Context(), "public", func(context.Context, string) (client.Credentials, error) {
+		return client.Credentials{ClientID: "public-id", ClientSecret: "public-secret"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "public-token", token)
+	assert.Equal(t, "default-token", tm.GetActiveToken(""))
+	mockOauthClient.AssertExpectations(t)
+}
+
+func TestTokenManager_ExpireToken(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	mockOauthClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", mock.Anything).Return(mockOauthClient)
+	mockOauthClient.On("LoginClient", mock.Anything, "client-id", "client-secret", "realm").
+		Return(&gocloak.JWT{AccessToken: "access-token", ExpiresIn: 300}, nil).Twice()
+
+	lookups := 0
+	lookup := func(context.Context, string) (client.Credentials, error) {
+		lookups++
+		return client.Credentials{ClientID: "client-id", ClientSecret: "client-secret"}, nil
+	}
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "", "", mockOauth)
+
+	_, err := tm.GetTenantToken(t.Context(), "tenant", lookup)
+	require.NoError(t, err)
+
+	tm.ExpireToken("tenant")
+	assert.Empty(t, tm.GetActiveToken("tenant"))
+
+	// The new login reuses the cached credentials
+	_, err = tm.GetTenantToken(t.Context(), "tenant", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lookups)
+	mockOauthClient.AssertExpectations(t)
+}
+
+func TestTokenManager_Ping(t *testing.T) {
+	mockOauth := new(mocks.MockIOauth)
+	mockOauthClient := new(mocks.MockIOauthClient)
+
+	mockOauth.On("NewClient", mock.Anything).Return(mockOauthClient)
+	mockOauthClient.On("GetIssuer", mock.Anything, "realm").Return(&gocloak.IssuerResponse{}, nil).Once()
+	mockOauthClient.On("GetIssuer", mock.Anything, "realm").Return(nil, errors.New("connection refused")).Once()
+
+	tm := client.NewTokenManager("http://keycloak.example.com", "realm", "client-id", "client-secret", mockOauth)
+
+	require.NoError(t, tm.Ping(t.Context()))
+	require.Error(t, tm.Ping(t.Context()))
+
+	// Reaching Keycloak needs no login
+	mockOauthClient.AssertNotCalled(t, "LoginClient", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockOauthClient.AssertExpectations(t)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxListPages bounds the pages read by a list request, in case the next links never end
+const maxListPages = 100
+
+// listPage is a page of a list response, Next is the link of the following page
+// and is empty on the last one
+type listPage[T any] struct {
+	Total  int    `json:"total"`
+	Next   string `json:"next,omitempty"`
+	Values []T    `json:"values"`
+}
+
+// listAll reads every page of a list endpoint, following the next links
+func listAll[T any](ctx context.Context, c *HelperClient, endpoint string) ([]T, error) {
+	var values []T
+	for range maxListPages {
+		var page listPage[T]
+		if err := c.DoAPIRequest(ctx, "GET", endpoint, nil, &page); err != nil {
+			return nil, err
+		}
+		values = append(values, page.Values...)
+
+		next, err := c.nextEndpoint(ctx, page.Next)
+		if err != nil {
+			return nil, err
+		}
+		if next == "" || next == endpoint || len(page.Values) == 0 || (page.Total > 0 && len(values) >= page.Total) {
+			return values, nil
+		}
+		endpoint = next
+	}
+	return nil, fmt.Errorf("list %s exceeds %d pages", endpoint, maxListPages)
+}
+
+// nextEndpoint returns the endpoint of a next link, which the API returns either as an
+// absolute URL on the API gateway or relative to it
+func (c *HelperClient) nextEndpoint(ctx context.Context, next string) (string, error) {
+	if next == "" {
+		return "", nil
+	}
+	link, err := url.Parse(next)
+	if err != nil {
+		return "", fmt.Errorf("invalid next page link %q: %w", next, err)
+	}
+	if !link.IsAbs() {
+		return next, nil
+	}
+
+	gateway, err := url.Parse(c.apiGateway(ctx))
+	if err != nil {
+		return "", fmt.Errorf("invalid api gateway url: %w", err)
+	}
+	if link.Host != gateway.Host || !strings.HasPrefix(link.Path, gateway.Path) {
+		return "", fmt.Errorf("next page link %q is not on the api gateway", next)
+	}
+	endpoint := strings.TrimPrefix(link.Path, strings.TrimSuffix(gateway.Path, "/"))
+	if link.RawQuery != "" {
+		endpoint += "?" + link.RawQuery
+	}
+	return endpoint, nil
+}
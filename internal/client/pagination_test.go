@@ -0,0 +1,64 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func pageResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}
+}
+
+func requestURL(url string) any {
+	return mock.MatchedBy(func(req *http.Request) bool { return req.URL.String() == url })
+}
+
+func TestListCloudServers_FollowsNextLinks(t *testing.T) {
+	const endpoint = "https://api.example.com/projects/p1/providers/Aruba.Compute/cloudServers"
+
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", requestURL(endpoint)).Return(pageResponse(
+		`{"total":3,"next":"`+endpoint+`?offset=1&limit=1","values":[{"metadata":{"id":"cs1"}}]}`), nil).Once()
+	mockHTTPClient.On("Do", requestURL(endpoint+"?offset=1&limit=1")).Return(pageResponse(
+		`{"total":3,"next":"/projects/p1/providers/Aruba.Compute/cloudServers?offset=2&limit=1","values":[{"metadata":{"id":"cs2"}}]}`), nil).Once()
+	mockHTTPClient.On("Do", requestURL(endpoint+"?offset=2&limit=1")).Return(pageResponse(
+		`{"total":3,"values":[{"metadata":{"id":"cs3"}}]}`), nil).Once()
+
+	helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+	ctx := client.WithAPIToken(context.Background(), "token")
+
+	list, err := helper.ListCloudServers(ctx, "p1")
+
+	require.NoError(t, err)
+	require.Len(t, list.Values, 3)
+	assert.Equal(t, 3, list.Total)
+	assert.Equal(t, "cs3", list.Values[2].Metadata.ID)
+	mockHTTPClient.AssertExpectations(t)
+}
+
+func TestListProjects_RejectsForeignNextLink(t *testing.T) {
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", requestURL("https://api.example.com/projects")).Return(pageResponse(
+		`{"total":2,"next":"https://elsewhere.example.com/projects?offset=1","values":[{"metadata":{"id":"p1"}}]}`), nil).Once()
+
+	helper := client.NewHelperClient(nil, mockHTTPClient, "https://api.example.com")
+	ctx := client.WithAPIToken(context.Background(), "token")
+
+	_, err := helper.ListProjects(ctx)
+
+	require.Error(t, err)
+	mockHTTPClient.AssertExpectations(t)
+}
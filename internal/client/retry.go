@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RetryPolicy controls how DoAPIRequest retries transient failures
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retries
+	MaxRetries int
+	// InitialBackoff is the base wait before the first retry, doubled at each retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between two attempts. A Retry-After longer than this is not waited for.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// retryableStatuses are the responses of an overloaded or temporarily unavailable API
+var retryableStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryDelay reports whether the attempt should be retried and how long to wait before.
+// GET, PUT and DELETE are idempotent and retried on any transient failure. A POST is only
+// retried when the API cannot have processed it: a 429 or a connection that was never established.
+func (p RetryPolicy) retryDelay(method string, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxRetries {
+		return 0, false
+	}
+
+	var retryAfter time.Duration
+	switch {
+	case err != nil:
+		if method == http.MethodPost && !isDialError(err) {
+			return 0, false
+		}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	case method != http.MethodPost && slices.Contains(retryableStatuses, resp.StatusCode):
+		retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	default:
+		return 0, false
+	}
+
+	if retryAfter > p.MaxBackoff {
+		// Let the reconcile requeue instead of blocking a worker
+		return 0, false
+	}
+	return max(p.backoff(attempt), retryAfter), true
+}
+
+// backoff returns the exponential backoff of an attempt with equal jitter
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 0; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.MaxBackoff)
+	if backoff <= 0 {
+		return 0
+	}
+	half := backoff / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+// isDialError reports whether err happened while connecting, before the request was sent
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// RateLimit is the token bucket applied to the API requests of each tenant
+type RateLimit struct {
+	// QPS is the sustained number of requests per second, 0 disables rate limiting
+	QPS float64
+	// Burst is the number of requests that can be sent at once
+	Burst int
+}
+
+// TenantRateLimiter keeps a token bucket per tenant, so a busy tenant cannot starve the others
+type TenantRateLimiter struct {
+	limit RateLimit
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewTenantRateLimiter creates a rate limiter applying limit to each tenant
+func NewTenantRateLimiter(limit RateLimit) *TenantRateLimiter {
+	return &TenantRateLimiter{
+		limit:    limit,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Wait blocks until a request of the tenant is allowed or ctx is done
+func (l *TenantRateLimiter) Wait(ctx context.Context, tenant string) error {
+	if l == nil || l.limit.QPS <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	limiter, ok := l.limiters[tenant]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(l.limit.QPS), max(l.limit.Burst, 1))
+		l.limiters[tenant] = limiter
+	}
+	l.mu.Unlock()
+
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,137 @@
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func statusResponse(statusCode int, retryAfter string) *http.Response {
+	header := make(http.Header)
+	if retryAfter != "" {
+		header.Set("Retry-After", retryAfter)
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+		Header:     header,
+	}
+}
+
+func retryingHelper(httpClient client.HTTPClient) *client.HelperClient {
+	helper := client.NewHelperClient(nil, httpClient, "https://api.example.com")
+	helper.RetryPolicy = client.RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+	return helper
+}
+
+func TestDoAPIRequest_RetriesIdempotentRequests(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		t.Run(method, func(t *testing.T) {
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			mockHTTPClient.On("Do", mock.Anything).Return(statusResponse(http.StatusServiceUnavailable, ""), nil).Once()
+			mockHTTPClient.On("Do", mock.Anything).Return(statusResponse(http.StatusBadGateway, ""), nil).Once()
+			mockHTTPClient.On("Do", mock.Anything).Return(okResponse(), nil).Once()
+
+			helper := retryingHelper(mockHTTPClient)
+			ctx := client.WithAPIToken(context.Background(), "token")
+
+			err := helper.DoAPIRequest(ctx, method, "/projects/p1", map[string]string{"name": "p1"}, nil)
+
+			require.NoError(t, err)
+			mockHTTPClient.AssertNumberOfCalls(t, "Do", 3)
+		})
+	}
+}
+
+func TestDoAPIRequest_GivesUpAfterMaxRetries(t *testing.T) {
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.Anything).Return(func(req *http.Request) (*http.Response, error) {
+		return statusResponse(http.StatusServiceUnavailable, ""), nil
+	}, nil)
+
+	helper := retryingHelper(mockHTTPClient)
+	ctx := client.WithAPIToken(context.Background(), "token")
+
+	err := helper.DoAPIRequest(ctx, http.MethodGet, "/projects", nil, nil)
+
+	var apiErr *client.ApiError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.Status)
+	mockHTTPClient.AssertNumberOfCalls(t, "Do", 4)
+}
+
+func TestDoAPIRequest_PostRetriedOnlyWhenSafe(t *testing.T) {
+	t.Run("server error", func(t *testing.T) {
+		mockHTTPClient := new(mocks.MockHTTPClient)
+		mockHTTPClient.On("Do", mock.Anything).Return(statusResponse(http.StatusServiceUnavailable, ""), nil)
+
+		helper := retryingHelper(mockHTTPClient)
+		ctx := client.WithAPIToken(context.Background(), "token")
+
+		err := helper.DoAPIRequest(ctx, http.MethodPost, "/projects", map[string]string{"name": "p1"}, nil)
+
+		require.Error(t, err)
+		mockHTTPClient.AssertNumberOfCalls(t, "Do", 1)
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		mockHTTPClient := new(mocks.MockHTTPClient)
+		mockHTTPClient.On("Do", mock.Anything).Return(statusResponse(http.StatusTooManyRequests, "0"), nil).Once()
+		mockHTTPClient.On("Do", mock.Anything).Return(okResponse(), nil).Once()
+
+		helper := retryingHelper(mockHTTPClient)
+		ctx := client.WithAPIToken(context.Background(), "token")
+
+		err := helper.DoAPIRequest(ctx, http.MethodPost, "/projects", map[string]string{"name": "p1"}, nil)
+
+		require.NoError(t, err)
+		mockHTTPClient.AssertNumberOfCalls(t, "Do", 2)
+	})
+}
+
+func TestDoAPIRequest_RetryAfterLongerThanMaxBackoff(t *testing.T) {
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.Anything).Return(statusResponse(http.StatusTooManyRequests, "120"), nil)
+
+	helper := retryingHelper(mockHTTPClient)
+	ctx := client.WithAPIToken(context.Background(), "token")
+
+	err := helper.DoAPIRequest(ctx, http.MethodGet, "/projects", nil, nil)
+
+	var apiErr *client.ApiError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.Status)
+	mockHTTPClient.AssertNumberOfCalls(t, "Do", 1)
+}
+
+func TestTenantRateLimiter_IsolatesTenants(t *testing.T) {
+	limiter := client.NewTenantRateLimiter(client.RateLimit{QPS: 0.001, Burst: 1})
+
+	require.NoError(t, limiter.Wait(context.Background(), "tenant-a"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, limiter.Wait(ctx, "tenant-a"), "tenant-a exhausted its burst")
+	assert.NoError(t, limiter.Wait(ctx, "tenant-b"), "tenant-b has its own bucket")
+}
+
+func TestTenantRateLimiter_Disabled(t *testing.T) {
+	limiter := client.NewTenantRateLimiter(client.RateLimit{})
+
+	for range 100 {
+		require.NoError(t, limiter.Wait(context.Background(), "tenant-a"))
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// Vault auth methods, as set by the vault-auth-method configuration key
+const (
+	VaultAuthAppRole    = "approle"
+	VaultAuthKubernetes = "kubernetes"
+	VaultAuthTokenFile  = "token-file"
+)
+
+const (
+	// DefaultKubernetesTokenFile is the service account token mounted in the pod
+	DefaultKubernetesTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// TenantPlaceholder is replaced by the tenant in the path template of the tenant secrets
+	TenantPlaceholder = "{{tenant}}"
+	// DefaultPathTemplate reads the secret named after the tenant
+	DefaultPathTemplate = TenantPlaceholder
+)
+
+// VaultAuth logs in to Vault with one auth method and returns the auth info of the token.
+// Implementations are comparable, so that a change of settings can be detected.
+type VaultAuth interface {
+	Login(ctx context.Context, cli IVaultClient) (*vault.SecretAuth, error)
+}
+
+// AppRoleAuth logs in with the role id and secret id of an AppRole
+type AppRoleAuth struct {
+	// Path is the mount path of the AppRole auth method
+	Path     string
+	RoleID   string
+	SecretID string
+}
+
+// Login writes the AppRole credentials to the login endpoint
+func (a AppRoleAuth) Login(_ context.Context, cli IVaultClient) (*vault.SecretAuth, error) {
+	return writeLogin(cli, "AppRole", a.Path, map[string]any{
+		"role_id":   a.RoleID,
+		"secret_id": a.SecretID,
+	})
+}
+
+// KubernetesAuth logs in with the service account token of the pod
+type KubernetesAuth struct {
+	// Path is the mount path of the Kubernetes auth method
+	Path string
+	// Role is the Vault role bound to the service account
+	Role string
+	// TokenFile is read at each login, as the kubelet rotates the projected token
+	TokenFile string
+}
+
+// Login writes the service account token to the login endpoint
+func (a KubernetesAuth) Login(_ context.Context, cli IVaultClient) (*vault.SecretAuth, error) {
+	jwt, err := readTokenFile(a.TokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account token: %w", err)
+	}
+	return writeLogin(cli, "Kubernetes", a.Path, map[string]any{
+		"role": a.Role,
+		"jwt":  jwt,
+	})
+}
+
+// TokenFileAuth uses the token written to a file, e.g. by a Vault Agent sink. The file is
+// read at each login, so a token rotated by the agent is picked up at the next re-login.
+// A token without TTL, e.g. a root token, is read again only once Vault rejects it.
+type TokenFileAuth struct {
+	File string
+}
+
+// Login reads the token and looks it up for its TTL
+func (a TokenFileAuth) Login(ctx context.Context, cli IVaultClient) (*vault.SecretAuth, error) {
+	token, err := readTokenFile(a.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault token: %w", err)
+	}
+	cli.SetToken(token)
+
+	secret, err := cli.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("token lookup failed: %w", err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("invalid lookup response")
+	}
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		return nil, fmt.Errorf("invalid token ttl: %w", err)
+	}
+	renewable, err := secret.TokenIsRenewable()
+	if err != nil {
+		return nil, fmt.Errorf("invalid token renewable flag: %w", err)
+	}
+	return &vault.SecretAuth{
+		ClientToken:   token,
+		LeaseDuration: int(ttl.Seconds()),
+		Renewable:     renewable,
+	}, nil
+}
+
+// writeLogin writes data to the login endpoint of the auth method mounted at path
+func writeLogin(cli IVaultClient, method, path string, data map[string]any) (*vault.SecretAuth, error) {
+	secret, err := cli.Logical().Write(fmt.Sprintf("auth/%v/login", path), data)
+	if err != nil {
+		return nil, fmt.Errorf("%s login failed: %w", method, err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return nil, fmt.Errorf("no auth info returned from Vault")
+	}
+	return secret.Auth, nil
+}
+
+// readTokenFile reads a token, trimming the trailing newline
+func readTokenFile(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("%s is empty", file)
+	}
+	return token, nil
+}
+
+// TenantSecretPath returns the path of the secret of tenant given the path template
+func TenantSecretPath(template, tenant string) string {
+	if template == "" {
+		template = DefaultPathTemplate
+	}
+	return strings.ReplaceAll(template, TenantPlaceholder, tenant)
+}
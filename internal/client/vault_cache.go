@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"golang.org/x/sync/singleflight"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DefaultSecretCacheTTL is how long a tenant secret read from Vault is used before it is read again
+const DefaultSecretCacheTTL = 5 * time.Minute
+
+// SecretCache holds the tenant secrets read from Vault. An entry is read again once its TTL,
+// or the lease of the secret when shorter, has passed. When Vault cannot be read the expired
+// entry is still returned, so an outage does not block the tenants already known.
+type SecretCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]*cachedSecret
+	// group deduplicates the concurrent reads of a tenant secret
+	group singleflight.Group
+}
+
+type cachedSecret struct {
+	data map[string]any
+	// version is the KV v2 version of the secret, 0 for KV v1
+	version int
+	expires time.Time
+}
+
+// NewSecretCache creates a SecretCache, a zero ttl reads the secrets at each request
+func NewSecretCache(ttl time.Duration) *SecretCache {
+	return &SecretCache{
+		ttl:     ttl,
+		entries: make(map[string]*cachedSecret),
+	}
+}
+
+// get returns the cached secret of tenant, reading it with read when missing or expired
+func (c *SecretCache) get(ctx context.Context, tenant string, read func(ctx context.Context) (*vault.KVSecret, error)) (map[string]any, error) {
+	if entry := c.entry(tenant); entry != nil && time.Now().Before(entry.expires) {
+		return entry.data, nil
+	}
+
+	data, err, _ := c.group.Do(tenant, func() (any, error) {
+		secret, err := read(ctx)
+		previous := c.entry(tenant)
+		if err != nil {
+			if previous != nil {
+				ctrl.Log.Error(err, "[vaultclient] failed to read tenant secret, using the cached one", "tenant", tenant)
+				return previous.data, nil
+			}
+			return nil, err
+		}
+
+		version := 0
+		if secret.VersionMetadata != nil {
+			version = secret.VersionMetadata.Version
+		}
+		if previous != nil && previous.version != version {
+			ctrl.Log.Info("[vaultclient] tenant secret changed", "tenant", tenant, "version", version)
+		}
+
+		c.mu.Lock()
+		ttl := c.ttl
+		if secret.Raw != nil && secret.Raw.LeaseDuration > 0 {
+			ttl = min(ttl, time.Duration(secret.Raw.LeaseDuration)*time.Second)
+		}
+		c.entries[tenant] = &cachedSecret{data: secret.Data, version: version, expires: time.Now().Add(ttl)}
+		c.mu.Unlock()
+		return secret.Data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data.(map[string]any), nil
+}
+
+func (c *SecretCache) entry(tenant string) *cachedSecret {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.entries[tenant]
+}
+
+// Invalidate drops the secret of tenant, so it is read again at the next request
+func (c *SecretCache) Invalidate(tenant string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, tenant)
+}
+
+// currentTTL returns the TTL of the cached secrets
+func (c *SecretCache) currentTTL() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ttl
+}
+
+// reset drops every secret and sets the TTL of the next ones
+func (c *SecretCache) reset(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+	clear(c.entries)
+}
@@ -2,21 +2,33 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"net/http"
 	"sync"
 	"time"
 
 	vault "github.com/hashicorp/vault/api"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/metrics"
+)
+
+const (
+	// loginInitialBackoff and loginMaxBackoff bound the wait between two failed logins
+	loginInitialBackoff = time.Second
+	loginMaxBackoff     = time.Minute
 )
 
 // VaultClient defines the interface your app will use
 type IVaultClient interface {
 	Logical() LogicalAPI
 	SetToken(token string)
+	KVv1(mount string) KvAPI
 	KVv2(mount string) KvAPI
 	SetNamespace(namespace string)
+	Address() string
+	SetAddress(address string) error
 	Auth() AuthAPI
 }
 
@@ -34,6 +46,7 @@ type AuthAPI interface {
 
 type AuthTokenAPI interface {
 	RenewSelfWithContext(ctx context.Context, increment int) (*vault.Secret, error)
+	LookupSelfWithContext(ctx context.Context) (*vault.Secret, error)
 }
 
 // Sleeper interface allows deterministic testing of time-based logic
@@ -55,6 +68,10 @@ type kvAPI struct {
 	kv *vault.KVv2
 }
 
+type kvV1API struct {
+	kv *vault.KVv1
+}
+
 type authAPI struct {
 	auth *vault.Auth
 }
@@ -63,156 +80,385 @@ type authTokenAPI struct {
 	token *vault.TokenAuth
 }
 
-// AppRoleClient implements VaultClient using AppRole auth
-type AppRoleClient struct {
+// VaultAuthClient reads the tenant secrets from Vault, logging in with a pluggable VaultAuth.
+// It is a manager.Runnable: the token is obtained and renewed while the manager runs.
+type VaultAuthClient struct {
 	client    IVaultClient
 	namespace string
-	rolePath  string
-	roleID    string
-	secretID  string
+	auth      VaultAuth
 	renewable bool
 	mu        sync.Mutex
+	// loginMu serialises the logins and renewals, so that the token of a reconfiguration is not overwritten
+	loginMu   sync.Mutex
 	ttl       time.Duration
-	sleeper   Sleeper
-	cancel    context.CancelFunc
-	KVMount   string
-}
-
-func VaultClient(address string) IVaultClient {
+	expiresAt time.Time
+	// authenticated is set by the first successful login, loginErr holds the last failed one
+	authenticated bool
+	loginErr      error
+	sleeper       Sleeper
+	cancel        context.CancelFunc
+	// relogin is signalled when Vault rejects the token, so a new login is made at once
+	relogin chan struct{}
+	KVMount string
+	// KVVersion is the version of the KV secrets engine, 1 or 2
+	KVVersion int
+	// PathTemplate is the path of the secret of a tenant, where TenantPlaceholder is replaced by the tenant
+	PathTemplate string
+	// secrets caches the tenant secrets, so the tenants are not serialised behind Vault latency
+	secrets *SecretCache
+}
+
+// VaultSettings are the Vault address, the login and the secrets engine settings of a VaultAuthClient
+type VaultSettings struct {
+	Address      string
+	Namespace    string
+	Auth         VaultAuth
+	KVMount      string
+	KVVersion    int
+	PathTemplate string
+	// SecretCacheTTL is how long a tenant secret is cached
+	SecretCacheTTL time.Duration
+}
+
+// VaultClient creates a Vault API client for the given address
+func VaultClient(address string) (IVaultClient, error) {
 	config := vault.DefaultConfig()
 	config.Address = address
 	client, err := vault.NewClient(config)
 	if err != nil {
-		ctrl.Log.Error(err, "Vault client initialization failed")
-		os.Exit(1)
+		return nil, fmt.Errorf("vault client initialization failed: %w", err)
+	}
+	return &VaultClientAPI{c: client}, nil
+}
+
+// NewVaultAuthClient creates a VaultAuthClient. It logs in once started by the manager.
+func NewVaultAuthClient(settings VaultSettings, cli IVaultClient) *VaultAuthClient {
+	return &VaultAuthClient{
+		client:       cli,
+		namespace:    settings.Namespace,
+		auth:         settings.Auth,
+		KVMount:      settings.KVMount,
+		KVVersion:    settings.KVVersion,
+		PathTemplate: settings.PathTemplate,
+		secrets:      NewSecretCache(settings.SecretCacheTTL),
+		sleeper:      realSleeper{},
+		relogin:      make(chan struct{}, 1),
 	}
-	return &VaultClientAPI{c: client}
 }
 
-// NewAppRoleClient creates and authenticates an AppRoleClient
-func NewAppRoleClient(namespace string, rolePath string, roleID string, secretID string, kvMount string, cli IVaultClient) (*AppRoleClient, error) {
+// NewAppRoleClient creates a VaultAuthClient logging in with AppRole and reading the
+// KV v2 secret named after the tenant
+func NewAppRoleClient(namespace string, rolePath string, roleID string, secretID string, kvMount string, cli IVaultClient) *VaultAuthClient {
+	return NewVaultAuthClient(VaultSettings{
+		Namespace:      namespace,
+		Auth:           AppRoleAuth{Path: rolePath, RoleID: roleID, SecretID: secretID},
+		KVMount:        kvMount,
+		KVVersion:      2,
+		PathTemplate:   DefaultPathTemplate,
+		SecretCacheTTL: DefaultSecretCacheTTL,
+	}, cli)
+}
+
+// Start logs in, retrying with backoff until it succeeds, then keeps the token renewed
+// until ctx is done. A token that cannot be renewed is replaced by a new login before it expires,
+// a token without TTL only once Vault rejects it.
+func (c *VaultAuthClient) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	c.mu.Lock()
+	c.cancel = cancel
+	c.mu.Unlock()
+	defer cancel()
 
-	c := &AppRoleClient{
-		client:    cli,
-		namespace: namespace,
-		rolePath:  rolePath,
-		KVMount:   kvMount,
-		roleID:    roleID,
-		secretID:  secretID,
-		sleeper:   realSleeper{},
-	}
+	for {
+		if !c.loginWithBackoff(ctx) {
+			return nil
+		}
+		if c.autoRenew(ctx) {
+			continue
+		}
 
-	if err := c.login(); err != nil {
-		return nil, err
+		var expiring <-chan time.Time
+		if wait := renewWait(c.tokenTTL()); wait > 0 {
+			expiring = c.sleeper.After(wait)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-expiring:
+		case <-c.relogin:
+		}
 	}
+}
 
-	// Start background renewal loop
-	c.StartAutoRenew()
-
-	return c, nil
+// NeedLeaderElection returns false: every replica needs a Vault token, for its readiness too
+func (c *VaultAuthClient) NeedLeaderElection() bool {
+	return false
 }
 
-func (c *AppRoleClient) Close() {
+// Close stops the token renewal
+func (c *VaultAuthClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.cancel != nil {
 		c.cancel()
 	}
 }
 
-// login authenticates using AppRole
-func (c *AppRoleClient) login() error {
-	data := map[string]any{
-		"role_id":   c.roleID,
-		"secret_id": c.secretID,
+// loginWithBackoff logs in until it succeeds, doubling the wait after each failure.
+// It returns false when ctx is done first.
+func (c *VaultAuthClient) loginWithBackoff(ctx context.Context) bool {
+	backoff := loginInitialBackoff
+	for {
+		err := c.login(ctx)
+		if err == nil {
+			return true
+		}
+		ctrl.Log.Error(err, "[vaultclient] login failed, retrying", "backoff", backoff)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-c.sleeper.After(backoff):
+		}
+		backoff = min(backoff*2, loginMaxBackoff)
 	}
+}
 
-	if c.namespace != "" {
-		c.client.SetNamespace(c.namespace)
+// login authenticates with the configured auth method
+func (c *VaultAuthClient) login(ctx context.Context) error {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	c.mu.Lock()
+	namespace, auth := c.namespace, c.auth
+	c.mu.Unlock()
+	return c.loginWith(ctx, namespace, auth)
+}
+
+// loginWith authenticates with auth in namespace and records the result. The caller holds loginMu.
+func (c *VaultAuthClient) loginWith(ctx context.Context, namespace string, auth VaultAuth) error {
+	err := c.authenticate(ctx, namespace, auth)
+	metrics.VaultLogins.WithLabelValues(metrics.ResultLabel(err)).Inc()
+
+	c.mu.Lock()
+	c.loginErr = err
+	if err == nil {
+		c.authenticated = true
 	}
+	c.mu.Unlock()
+	return err
+}
 
-	appRoleURI := fmt.Sprintf("auth/%v/login", c.rolePath)
-	ctrl.Log.V(1).Info("trying Vault login", "uri", appRoleURI)
+// authenticate logs in with the auth method and stores the returned token
+func (c *VaultAuthClient) authenticate(ctx context.Context, namespace string, auth VaultAuth) error {
+	if auth == nil {
+		return fmt.Errorf("no vault auth method configured")
+	}
 
-	secret, err := c.client.Logical().Write(appRoleURI, data)
-	ctrl.Log.V(1).Info("[DEBUG] Secret received", "secret", secret)
+	if namespace != "" {
+		c.client.SetNamespace(namespace)
+	}
 
+	secretAuth, err := auth.Login(ctx, c.client)
 	if err != nil {
-		return fmt.Errorf("AppRole login failed: %w", err)
+		return err
 	}
 
-	if secret.Auth == nil {
-		return fmt.Errorf("no auth info returned from Vault")
+	c.client.SetToken(secretAuth.ClientToken)
+	c.mu.Lock()
+	c.ttl = time.Duration(secretAuth.LeaseDuration) * time.Second
+	c.renewable = secretAuth.Renewable
+	c.expiresAt = tokenExpiry(c.ttl)
+	c.mu.Unlock()
+	ctrl.Log.V(1).Info("[Vault] Authenticated!", "ttl", c.ttl, "renewable", c.renewable)
+	return nil
+}
+
+// Reconfigure replaces the Vault address, the auth method and the secrets engine settings.
+// When the address or the login settings changed, it logs in with the new ones first: if the
+// login fails, the previous settings and token stay in effect and the error is returned.
+func (c *VaultAuthClient) Reconfigure(settings VaultSettings) error {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	c.mu.Lock()
+	previousAddress, previousNamespace := c.client.Address(), c.namespace
+	addressChanged := settings.Address != "" && settings.Address != previousAddress
+	namespaceChanged := settings.Namespace != previousNamespace
+	loginChanged := addressChanged || namespaceChanged || settings.Auth != c.auth
+	secretsChanged := loginChanged || settings.KVMount != c.KVMount || settings.KVVersion != c.KVVersion ||
+		settings.PathTemplate != c.PathTemplate || settings.SecretCacheTTL != c.secrets.currentTTL()
+	c.mu.Unlock()
+
+	if loginChanged {
+		ctrl.Log.Info("[vaultclient] configuration changed, logging in again", "address", settings.Address)
+		if addressChanged {
+			if err := c.client.SetAddress(settings.Address); err != nil {
+				return fmt.Errorf("invalid vault address: %w", err)
+			}
+		}
+		if namespaceChanged {
+			c.client.SetNamespace(settings.Namespace)
+		}
+		if err := c.loginWith(context.Background(), settings.Namespace, settings.Auth); err != nil {
+			if addressChanged {
+				_ = c.client.SetAddress(previousAddress)
+			}
+			if namespaceChanged {
+				c.client.SetNamespace(previousNamespace)
+			}
+			return fmt.Errorf("vault login with the new settings failed: %w", err)
+		}
 	}
 
-	c.client.SetToken(secret.Auth.ClientToken)
-	c.ttl = time.Duration(secret.Auth.LeaseDuration) * time.Second
-	c.renewable = secret.Auth.Renewable
-	ctrl.Log.V(1).Info("[Vault] Authenticated! TTL: %d seconds, Renewable: %v", c.ttl, c.renewable)
+	c.mu.Lock()
+	c.namespace = settings.Namespace
+	c.auth = settings.Auth
+	c.KVMount = settings.KVMount
+	c.KVVersion = settings.KVVersion
+	c.PathTemplate = settings.PathTemplate
+	c.mu.Unlock()
+	if secretsChanged {
+		c.secrets.reset(settings.SecretCacheTTL)
+	}
 	return nil
 }
 
-// GetSecret reads a KVv2 secret
-func (c *AppRoleClient) GetSecret(ctx context.Context, path string) (map[string]any, error) {
+// GetSecret reads a secret of the KV secrets engine, of version KVVersion
+func (c *VaultAuthClient) GetSecret(ctx context.Context, path string) (map[string]any, error) {
+	secret, err := c.readSecret(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+// readSecret reads a secret with its metadata. The lock only guards the settings, so
+// concurrent reads are not serialised behind Vault latency.
+func (c *VaultAuthClient) readSecret(ctx context.Context, path string) (*vault.KVSecret, error) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	var kv KvAPI
+	if c.KVVersion == 1 {
+		kv = c.client.KVv1(c.KVMount)
+	} else {
+		kv = c.client.KVv2(c.KVMount)
+	}
+	c.mu.Unlock()
 
-	secret, err := c.client.KVv2(c.KVMount).Get(ctx, path)
+	secret, err := kv.Get(ctx, path)
 	if err != nil {
+		if isTokenRejected(err) {
+			c.requestLogin()
+		}
 		return nil, err
 	}
-	return secret.Data, nil
+	if secret == nil {
+		return nil, fmt.Errorf("secret %s not found", path)
+	}
+	return secret, nil
 }
 
-// autoRenew starts a background goroutine to renew the token
-func (c *AppRoleClient) autoRenew(ctx context.Context) {
-	if !c.renewable {
+// GetTenantSecret returns the secret of a tenant, at the path given by PathTemplate.
+// The secret is cached, see SecretCache.
+func (c *VaultAuthClient) GetTenantSecret(ctx context.Context, tenant string) (map[string]any, error) {
+	c.mu.Lock()
+	path := TenantSecretPath(c.PathTemplate, tenant)
+	c.mu.Unlock()
+	return c.secrets.get(ctx, tenant, func(ctx context.Context) (*vault.KVSecret, error) {
+		return c.readSecret(ctx, path)
+	})
+}
+
+// InvalidateTenantSecret drops the cached secret of a tenant, e.g. after its credentials were rejected
+func (c *VaultAuthClient) InvalidateTenantSecret(tenant string) {
+	c.secrets.Invalidate(tenant)
+}
+
+// autoRenew renews the token until ctx is done or a new login is needed, which it reports.
+// It returns false at once for a token that is not renewable or does not expire.
+func (c *VaultAuthClient) autoRenew(ctx context.Context) bool {
+	c.mu.Lock()
+	renewable := c.renewable
+	c.mu.Unlock()
+	if !renewable {
 		ctrl.Log.V(1).Info("[vaultclient] token not renewable, stopping autoRenew")
-		return
+		return false
+	}
+	if c.tokenTTL() <= 0 {
+		ctrl.Log.V(1).Info("[vaultclient] token does not expire, stopping autoRenew")
+		return false
 	}
 
 	if c.sleeper == nil {
 		c.sleeper = realSleeper{} // use real sleeper in production
 	}
-	renewBefore := c.ttl / 5 // renew at 80% of TTL
-	if renewBefore < 10*time.Second {
-		renewBefore = 10 * time.Second
-	}
 	for {
-		wait := c.ttl - renewBefore
-		if wait <= 0 {
-			wait = 1 * time.Second
-		}
+		wait := renewWait(c.tokenTTL())
 		select {
 		case <-ctx.Done():
 			ctrl.Log.V(1).Info("[vaultclient] autoRenew stopped")
-			return
+			return false
 		case <-c.sleeper.After(wait):
-			if err := c.renewSelf(ctx); err != nil {
+			err := c.renewSelf(ctx)
+			metrics.VaultRenewals.WithLabelValues(metrics.ResultLabel(err)).Inc()
+			if err != nil {
+				// Start logs in again, with backoff until it succeeds
 				ctrl.Log.V(1).Info("[vaultclient] renew failed — re-login", "error", err)
-				_ = c.login()
+				return true
 			}
+		case <-c.relogin:
+			ctrl.Log.V(1).Info("[vaultclient] token rejected — re-login")
+			return true
 		}
 	}
 }
 
-func (c *AppRoleClient) StartAutoRenew() {
-	ctx, cancel := context.WithCancel(context.Background())
-	c.cancel = cancel
-	go func() {
-		// Use the same context for clean stop
-		// but protect against immediate cancellation
-		if ctx.Err() != nil {
-			ctrl.Log.V(1).Info("[vaultclient] autoRenew context was canceled immediately — recreating")
-			ctx = context.Background()
-		}
-		c.autoRenew(ctx)
-	}()
+// requestLogin asks Start for a new login, without waiting for it
+func (c *VaultAuthClient) requestLogin() {
+	select {
+	case c.relogin <- struct{}{}:
+	default:
+	}
+}
+
+// isTokenRejected reports whether Vault refused the token, with a 403 for an invalid or
+// revoked token or a 401
+func isTokenRejected(err error) bool {
+	var respErr *vault.ResponseError
+	return errors.As(err, &respErr) &&
+		(respErr.StatusCode == http.StatusUnauthorized || respErr.StatusCode == http.StatusForbidden)
 }
 
-func (c *AppRoleClient) renewSelf(ctx context.Context) error {
+// tokenTTL returns the TTL of the current token
+func (c *VaultAuthClient) tokenTTL() time.Duration {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	return c.ttl
+}
 
-	secret, err := c.client.Auth().Token().RenewSelfWithContext(ctx, int(c.ttl.Seconds()))
+// renewWait returns how long to wait before renewing a token with the given TTL:
+// 80% of the TTL, keeping at least 10 seconds of margin. It returns 0 for a token
+// without TTL, which never needs renewing.
+func renewWait(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return 0
+	}
+	renewBefore := max(ttl/5, 10*time.Second)
+	wait := ttl - renewBefore
+	if wait <= 0 {
+		wait = 1 * time.Second
+	}
+	return wait
+}
+
+// renewSelf renews the token. The lock is not held during the call to Vault, loginMu keeps a
+// login from replacing the token meanwhile.
+func (c *VaultAuthClient) renewSelf(ctx context.Context) error {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	ttl := c.tokenTTL()
+	secret, err := c.client.Auth().Token().RenewSelfWithContext(ctx, int(ttl.Seconds()))
 	if err != nil {
 		return fmt.Errorf("renew self: %w", err)
 	}
@@ -220,18 +466,65 @@ func (c *AppRoleClient) renewSelf(ctx context.Context) error {
 		return fmt.Errorf("invalid renew response")
 	}
 
-	c.ttl = time.Duration(secret.Auth.LeaseDuration) * time.Second
-	c.renewable = secret.Auth.Renewable
+	renewed := time.Duration(secret.Auth.LeaseDuration) * time.Second
 	c.client.SetToken(secret.Auth.ClientToken)
-	ctrl.Log.V(1).Info("[vaultclient] token renewed", "ttl", c.ttl)
+	c.mu.Lock()
+	c.ttl = renewed
+	c.renewable = secret.Auth.Renewable
+	c.expiresAt = tokenExpiry(renewed)
+	c.mu.Unlock()
+	ctrl.Log.V(1).Info("[vaultclient] token renewed", "ttl", renewed)
+	return nil
+}
+
+// CheckToken confirms that the Vault token is not expired and still accepted by Vault
+func (c *VaultAuthClient) CheckToken(ctx context.Context) error {
+	c.mu.Lock()
+	authenticated, loginErr, expiresAt := c.authenticated, c.loginErr, c.expiresAt
+	c.mu.Unlock()
+
+	if !authenticated {
+		if loginErr != nil {
+			return fmt.Errorf("vault login pending: %w", loginErr)
+		}
+		return fmt.Errorf("vault login pending")
+	}
+
+	if !expiresAt.IsZero() && time.Now().After(expiresAt) {
+		return fmt.Errorf("vault token expired at %s", expiresAt.Format(time.RFC3339))
+	}
+
+	secret, err := c.client.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("lookup self: %w", err)
+	}
+	if secret == nil || secret.Data == nil {
+		return fmt.Errorf("invalid lookup response")
+	}
 	return nil
 }
 
+// tokenExpiry returns when a token with the given TTL expires, zero for tokens that never expire
+func tokenExpiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
 // implement IVaultClient methods
 func (v *VaultClientAPI) SetNamespace(namespace string) {
 	v.c.SetNamespace(namespace)
 }
 
+func (v *VaultClientAPI) Address() string {
+	return v.c.Address()
+}
+
+func (v *VaultClientAPI) SetAddress(address string) error {
+	return v.c.SetAddress(address)
+}
+
 func (v *VaultClientAPI) Auth() AuthAPI {
 	return &authAPI{auth: v.c.Auth()}
 }
@@ -243,6 +536,10 @@ func (v *VaultClientAPI) SetToken(token string) {
 func (v *VaultClientAPI) Logical() LogicalAPI {
 	return &logicalAPI{l: v.c.Logical()}
 }
+func (v *VaultClientAPI) KVv1(mount string) KvAPI {
+	return &kvV1API{kv: v.c.KVv1(mount)}
+}
+
 func (v *VaultClientAPI) KVv2(mount string) KvAPI {
 	return &kvAPI{kv: v.c.KVv2(mount)}
 }
@@ -257,11 +554,19 @@ func (a *authTokenAPI) RenewSelfWithContext(ctx context.Context, increment int)
 	return a.token.RenewSelfWithContext(ctx, increment)
 }
 
+func (a *authTokenAPI) LookupSelfWithContext(ctx context.Context) (*vault.Secret, error) {
+	return a.token.LookupSelfWithContext(ctx)
+}
+
 // implement KvAPI methods
 func (k *kvAPI) Get(ctx context.Context, path string) (*vault.KVSecret, error) {
 	return k.kv.Get(ctx, path)
 }
 
+func (k *kvV1API) Get(ctx context.Context, path string) (*vault.KVSecret, error) {
+	return k.kv.Get(ctx, path)
+}
+
 // implement Sleeper methods
 func (r realSleeper) After(d time.Duration) <-chan time.Time {
 	return time.After(d)
@@ -273,35 +578,45 @@ func (la *logicalAPI) Write(path string, data map[string]any) (*vault.Secret, er
 }
 
 // Helper functions for testing
-func (a *AppRoleClient) LoginHelper() error {
-	return a.login()
+func (a *VaultAuthClient) LoginHelper() error {
+	return a.login(context.Background())
 }
 
-func (a *AppRoleClient) RenewSelfHelper(ctx context.Context) error {
+func (a *VaultAuthClient) SetSleeperHelper(sleeper Sleeper) {
+	a.sleeper = sleeper
+}
+
+func (a *VaultAuthClient) RenewSelfHelper(ctx context.Context) error {
 	return a.renewSelf(ctx)
 }
 
-func NewAppRoleClientHelper(namespace string, rolePath string, roleID string, secretID string, kvMount string, mockClient IVaultClient) (*AppRoleClient, error) {
-	return &AppRoleClient{
-		client:    mockClient,
-		namespace: namespace,
-		rolePath:  rolePath,
-		roleID:    roleID,
-		KVMount:   kvMount,
-		secretID:  secretID,
+func RenewWaitHelper(ttl time.Duration) time.Duration {
+	return renewWait(ttl)
+}
+
+func NewAppRoleClientHelper(namespace string, rolePath string, roleID string, secretID string, kvMount string, mockClient IVaultClient) (*VaultAuthClient, error) {
+	return &VaultAuthClient{
+		client:       mockClient,
+		namespace:    namespace,
+		auth:         AppRoleAuth{Path: rolePath, RoleID: roleID, SecretID: secretID},
+		KVMount:      kvMount,
+		KVVersion:    2,
+		PathTemplate: DefaultPathTemplate,
+		secrets:      NewSecretCache(DefaultSecretCacheTTL),
 	}, nil
 }
 
-func AutoRenewHelper(namespace string, rolePath string, roleID string, secretID string, kvMount string, mockClient IVaultClient, timeToWaitMs int, sleep Sleeper) (*AppRoleClient, error) {
-	c := &AppRoleClient{
-		client:    mockClient,
-		namespace: namespace,
-		rolePath:  rolePath,
-		roleID:    roleID,
-		KVMount:   kvMount,
-		secretID:  secretID,
-		renewable: true,
-		sleeper:   sleep,
+func AutoRenewHelper(namespace string, rolePath string, roleID string, secretID string, kvMount string, mockClient IVaultClient, timeToWaitMs int, sleep Sleeper) (*VaultAuthClient, error) {
+	c := &VaultAuthClient{
+		client:       mockClient,
+		namespace:    namespace,
+		auth:         AppRoleAuth{Path: rolePath, RoleID: roleID, SecretID: secretID},
+		KVMount:      kvMount,
+		KVVersion:    2,
+		PathTemplate: DefaultPathTemplate,
+		secrets:      NewSecretCache(DefaultSecretCacheTTL),
+		renewable:    true,
+		sleeper:      sleep,
 	}
 
 	c.ttl = time.Duration(timeToWaitMs) * time.Millisecond
@@ -1,7 +1,11 @@
 package client_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -95,7 +99,8 @@ func TestLogin(t *testing.T) {
 				mock.Anything,
 			).Return(tt.writeReturn, tt.writeErr)
 
-			_, err := client.NewAppRoleClient("test-namespace", "test-role-path", "test-role-id", "test-secret-id", "kv", mockClient)
+			c := client.NewAppRoleClient("test-namespace", "test-role-path", "test-role-id", "test-secret-id", "kv", mockClient)
+			err := c.LoginHelper()
 
 			if tt.expectedErr {
 				require.Error(t, err)
@@ -157,3 +162,429 @@ func TestAutoRenew_TriggersRenewal(t *testing.T) {
 
 	mockToken.AssertCalled(t, "RenewSelfWithContext", mock.Anything, mock.AnythingOfType("int"))
 }
+
+func TestCheckToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		loginErr    error
+		lookup      *vault.Secret
+		lookupErr   error
+		expectedErr bool
+	}{
+		{
+			name:   "valid token",
+			lookup: &vault.Secret{Data: map[string]any{"ttl": 3600}},
+		},
+		{
+			name:        "login pending",
+			loginErr:    fmt.Errorf("connection refused"),
+			expectedErr: true,
+		},
+		{
+			name:        "lookup error",
+			lookupErr:   fmt.Errorf("permission denied"),
+			expectedErr: true,
+		},
+		{
+			name:        "empty lookup response",
+			lookup:      &vault.Secret{},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockToken := new(mocks.MockAuthTokenAPI)
+			mockAuth := new(mocks.MockAuthAPI)
+			mockClient := new(mocks.MockIVaultClient)
+
+			mockLogical := new(mocks.MockLogicalAPI)
+			mockClient.On("SetNamespace", "test-namespace").Return()
+			mockClient.On("Logical").Return(mockLogical)
+			mockClient.On("SetToken", mock.Anything).Return()
+			mockLogical.On("Write", "auth/test-role-path/login", mock.Anything).
+				Return(&vault.Secret{Auth: &vault.SecretAuth{ClientToken: "token-test", LeaseDuration: 3600}}, tt.loginErr)
+			if tt.loginErr == nil {
+				mockClient.On("Auth").Return(mockAuth)
+				mockAuth.On("Token").Return(mockToken)
+				mockToken.On("LookupSelfWithContext", mock.Anything).Return(tt.lookup, tt.lookupErr)
+			}
+
+			c := client.NewAppRoleClient("test-namespace", "test-role-path", "test-role-id", "test-secret-id", "kv", mockClient)
+			_ = c.LoginHelper()
+
+			err := c.CheckToken(t.Context())
+
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			mockToken.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStart_RetriesLogin(t *testing.T) {
+	mockLogical := new(mocks.MockLogicalAPI)
+	mockClient := new(mocks.MockIVaultClient)
+
+	mockClient.On("SetNamespace", "test-namespace").Return()
+	mockClient.On("Logical").Return(mockLogical)
+	mockClient.On("SetToken", "token-test").Return()
+	mockLogical.On("Write", "auth/test-role-path/login", mock.Anything).
+		Return(nil, fmt.Errorf("connection refused")).Once()
+	loggedIn := make(chan struct{})
+	mockLogical.On("Write", "auth/test-role-path/login", mock.Anything).
+		Return(&vault.Secret{Auth: &vault.SecretAuth{ClientToken: "token-test", LeaseDuration: 3600}}, nil).Once().
+		Run(func(mock.Arguments) { close(loggedIn) })
+
+	// Backoffs elapse at once, the wait before the next login never does
+	mockSleeper := new(mocks.MockSleeper)
+	mockSleeper.On("After", time.Second).Return(func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	})
+	mockSleeper.On("After", mock.Anything).Return(func(time.Duration) <-chan time.Time {
+		return make(chan time.Time)
+	})
+
+	c := client.NewAppRoleClient("test-namespace", "test-role-path", "test-role-id", "test-secret-id", "kv", mockClient)
+	c.SetSleeperHelper(mockSleeper)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() { done <- c.Start(ctx) }()
+
+	select {
+	case <-loggedIn:
+	case <-time.After(time.Second):
+		t.Fatal("login not retried")
+	}
+	cancel()
+
+	require.NoError(t, <-done)
+	mockLogical.AssertExpectations(t)
+}
+
+func TestRenewWait(t *testing.T) {
+	require.Equal(t, 48*time.Minute, client.RenewWaitHelper(time.Hour))
+	require.Equal(t, 20*time.Second, client.RenewWaitHelper(30*time.Second))
+	require.Equal(t, time.Second, client.RenewWaitHelper(5*time.Second))
+	require.Zero(t, client.RenewWaitHelper(0), "a token without TTL is never renewed")
+}
+
+func TestStart_TokenWithoutTTL(t *testing.T) {
+	mockLogical := new(mocks.MockLogicalAPI)
+	mockKV := new(mocks.MockKvAPI)
+	mockClient := new(mocks.MockIVaultClient)
+
+	mockClient.On("Logical").Return(mockLogical)
+	mockClient.On("SetToken", "token-test").Return()
+	mockClient.On("KVv2", "secret").Return(mockKV)
+	mockKV.On("Get", mock.Anything, "tenant-a").Return(nil, &vault.ResponseError{StatusCode: 403})
+	logins := make(chan struct{}, 2)
+	mockLogical.On("Write", "auth/approle/login", mock.Anything).
+		Return(&vault.Secret{Auth: &vault.SecretAuth{ClientToken: "token-test", LeaseDuration: 0}}, nil).
+		Run(func(mock.Arguments) { logins <- struct{}{} })
+
+	// No wait is expected: the token is replaced only once Vault rejects it
+	c := client.NewVaultAuthClient(client.VaultSettings{
+		Auth:      client.AppRoleAuth{Path: "approle", RoleID: "role-id", SecretID: "secret-id"},
+		KVMount:   "secret",
+		KVVersion: 2,
+	}, mockClient)
+	c.SetSleeperHelper(new(mocks.MockSleeper))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() { done <- c.Start(ctx) }()
+
+	<-logins
+	select {
+	case <-logins:
+		t.Fatal("token without TTL logged in again")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_, err := c.GetTenantSecret(ctx, "tenant-a")
+	require.Error(t, err)
+	select {
+	case <-logins:
+	case <-time.After(time.Second):
+		t.Fatal("rejected token not replaced")
+	}
+	cancel()
+
+	require.NoError(t, <-done)
+	mockLogical.AssertNumberOfCalls(t, "Write", 2)
+}
+
+func TestKubernetesAuth_Login(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("service-account-jwt\n"), 0o600))
+
+	mockLogical := new(mocks.MockLogicalAPI)
+	mockClient := new(mocks.MockIVaultClient)
+	mockClient.On("Logical").Return(mockLogical)
+	mockClient.On("SetToken", "token-test").Return()
+	mockLogical.On("Write", "auth/kubernetes/login", map[string]any{"role": "operator", "jwt": "service-account-jwt"}).
+		Return(&vault.Secret{Auth: &vault.SecretAuth{ClientToken: "token-test", LeaseDuration: 3600}}, nil)
+
+	c := client.NewVaultAuthClient(client.VaultSettings{
+		Auth: client.KubernetesAuth{Path: "kubernetes", Role: "operator", TokenFile: tokenFile},
+	}, mockClient)
+
+	require.NoError(t, c.LoginHelper())
+	mockClient.AssertExpectations(t)
+	mockLogical.AssertExpectations(t)
+}
+
+func TestTokenFileAuth_Login(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       string
+		renewable bool
+	}{
+		{name: "token with ttl", ttl: "3600", renewable: true},
+		{name: "token without ttl", ttl: "0", renewable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenFile := filepath.Join(t.TempDir(), "token")
+			require.NoError(t, os.WriteFile(tokenFile, []byte("agent-token"), 0o600))
+
+			mockToken := new(mocks.MockAuthTokenAPI)
+			mockAuth := new(mocks.MockAuthAPI)
+			mockClient := new(mocks.MockIVaultClient)
+			mockClient.On("SetToken", "agent-token").Return()
+			mockClient.On("Auth").Return(mockAuth)
+			mockAuth.On("Token").Return(mockToken)
+			mockToken.On("LookupSelfWithContext", mock.Anything).
+				Return(&vault.Secret{Data: map[string]any{"ttl": json.Number(tt.ttl), "renewable": tt.renewable}}, nil)
+
+			auth, err := client.TokenFileAuth{File: tokenFile}.Login(t.Context(), mockClient)
+
+			require.NoError(t, err)
+			require.Equal(t, "agent-token", auth.ClientToken)
+			require.Equal(t, tt.ttl, fmt.Sprint(auth.LeaseDuration))
+			require.Equal(t, tt.renewable, auth.Renewable)
+		})
+	}
+}
+
+func TestStart_TokenFileWithoutTTL(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("root-token"), 0o600))
+
+	mockToken := new(mocks.MockAuthTokenAPI)
+	mockAuth := new(mocks.MockAuthAPI)
+	mockClient := new(mocks.MockIVaultClient)
+	mockClient.On("SetToken", "root-token").Return()
+	mockClient.On("Auth").Return(mockAuth)
+	mockAuth.On("Token").Return(mockToken)
+	lookups := make(chan struct{}, 2)
+	mockToken.On("LookupSelfWithContext", mock.Anything).
+		Return(&vault.Secret{Data: map[string]any{"ttl": json.Number("0"), "renewable": false}}, nil).
+		Run(func(mock.Arguments) { lookups <- struct{}{} })
+
+	c := client.NewVaultAuthClient(client.VaultSettings{Auth: client.TokenFileAuth{File: tokenFile}}, mockClient)
+	c.SetSleeperHelper(new(mocks.MockSleeper))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() { done <- c.Start(ctx) }()
+
+	<-lookups
+	select {
+	case <-lookups:
+		t.Fatal("token without TTL read again")
+	case <-time.After(100 * time.Millisecond):
+	}
+	cancel()
+
+	require.NoError(t, <-done)
+	mockToken.AssertNumberOfCalls(t, "LookupSelfWithContext", 1)
+}
+
+func TestGetTenantSecret(t *testing.T) {
+	tests := []struct {
+		name      string
+		kvVersion int
+	}{
+		{name: "kv v1", kvVersion: 1},
+		{name: "kv v2", kvVersion: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockKV := new(mocks.MockKvAPI)
+			mockClient := new(mocks.MockIVaultClient)
+			mockClient.On(fmt.Sprintf("KVv%d", tt.kvVersion), "secret").Return(mockKV)
+			mockKV.On("Get", mock.Anything, "aruba/tenant-a/api").
+				Return(&vault.KVSecret{Data: map[string]any{"client-id": "client-id"}}, nil)
+
+			c := client.NewVaultAuthClient(client.VaultSettings{
+				KVMount:      "secret",
+				KVVersion:    tt.kvVersion,
+				PathTemplate: "aruba/{{tenant}}/api",
+			}, mockClient)
+
+			data, err := c.GetTenantSecret(t.Context(), "tenant-a")
+
+			require.NoError(t, err)
+			require.Equal(t, "client-id", data["client-id"])
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetTenantSecret_Cache(t *testing.T) {
+	mockKV := new(mocks.MockKvAPI)
+	mockClient := new(mocks.MockIVaultClient)
+	mockClient.On("KVv2", "secret").Return(mockKV)
+	mockKV.On("Get", mock.Anything, "tenant-a").
+		Return(&vault.KVSecret{Data: map[string]any{"client-id": "v1"}, VersionMetadata: &vault.KVVersionMetadata{Version: 1}}, nil).Once()
+	mockKV.On("Get", mock.Anything, "tenant-a").
+		Return(&vault.KVSecret{Data: map[string]any{"client-id": "v2"}, VersionMetadata: &vault.KVVersionMetadata{Version: 2}}, nil).Once()
+
+	c := client.NewVaultAuthClient(client.VaultSettings{KVMount: "secret", KVVersion: 2, SecretCacheTTL: time.Hour}, mockClient)
+
+	data, err := c.GetTenantSecret(t.Context(), "tenant-a")
+	require.NoError(t, err)
+	require.Equal(t, "v1", data["client-id"])
+
+	// Served from the cache until invalidated
+	data, err = c.GetTenantSecret(t.Context(), "tenant-a")
+	require.NoError(t, err)
+	require.Equal(t, "v1", data["client-id"])
+
+	c.InvalidateTenantSecret("tenant-a")
+	data, err = c.GetTenantSecret(t.Context(), "tenant-a")
+	require.NoError(t, err)
+	require.Equal(t, "v2", data["client-id"])
+	mockKV.AssertExpectations(t)
+}
+
+func TestGetTenantSecret_VaultUnavailable(t *testing.T) {
+	mockKV := new(mocks.MockKvAPI)
+	mockClient := new(mocks.MockIVaultClient)
+	mockClient.On("KVv2", "secret").Return(mockKV)
+	mockKV.On("Get", mock.Anything, "tenant-a").
+		Return(&vault.KVSecret{Data: map[string]any{"client-id": "client-id"}}, nil).Once()
+	mockKV.On("Get", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("connection refused"))
+
+	// Without a TTL every request reads Vault
+	c := client.NewVaultAuthClient(client.VaultSettings{KVMount: "secret", KVVersion: 2}, mockClient)
+
+	_, err := c.GetTenantSecret(t.Context(), "tenant-a")
+	require.NoError(t, err)
+
+	data, err := c.GetTenantSecret(t.Context(), "tenant-a")
+	require.NoError(t, err, "the known secret is used while Vault is unavailable")
+	require.Equal(t, "client-id", data["client-id"])
+
+	_, err = c.GetTenantSecret(t.Context(), "tenant-b")
+	require.Error(t, err)
+}
+
+func TestReconfigure_LoginFailedKeepsSettings(t *testing.T) {
+	mockLogical := new(mocks.MockLogicalAPI)
+	mockKV := new(mocks.MockKvAPI)
+	mockClient := new(mocks.MockIVaultClient)
+	mockClient.On("Address").Return("https://vault.example.com")
+	mockClient.On("SetAddress", mock.Anything).Return(nil)
+	mockClient.On("Logical").Return(mockLogical)
+	mockClient.On("SetToken", mock.Anything).Return()
+	mockClient.On("KVv2", "secret").Return(mockKV)
+	mockLogical.On("Write", "auth/approle2/login", mock.Anything).Return(nil, fmt.Errorf("permission denied")).Once()
+	mockLogical.On("Write", "auth/approle2/login", mock.Anything).Return(&vault.Secret{
+		Auth: &vault.SecretAuth{ClientToken: "token-test", LeaseDuration: 3600, Renewable: true},
+	}, nil).Once()
+	mockKV.On("Get", mock.Anything, "tenant-a").Return(&vault.KVSecret{Data: map[string]any{"client-id": "client-id"}}, nil)
+
+	c := client.NewVaultAuthClient(client.VaultSettings{
+		Address:      "https://vault.example.com",
+		Auth:         client.AppRoleAuth{Path: "approle", RoleID: "role-id", SecretID: "secret-id"},
+		KVMount:      "secret",
+		KVVersion:    2,
+		PathTemplate: client.DefaultPathTemplate,
+	}, mockClient)
+	settings := client.VaultSettings{
+		Address:      "https://vault2.example.com",
+		Auth:         client.AppRoleAuth{Path: "approle2", RoleID: "role-id", SecretID: "secret-id"},
+		KVMount:      "secret2",
+		KVVersion:    2,
+		PathTemplate: client.DefaultPathTemplate,
+	}
+
+	err := c.Reconfigure(settings)
+	require.Error(t, err)
+	mockClient.AssertCalled(t, "SetAddress", "https://vault.example.com")
+	mockClient.AssertNotCalled(t, "SetToken", mock.Anything)
+
+	// The previous secrets engine settings are still in use
+	_, err = c.GetTenantSecret(t.Context(), "tenant-a")
+	require.NoError(t, err)
+
+	// The retry logs in again, as nothing was applied by the failed attempt
+	require.NoError(t, c.Reconfigure(settings))
+	mockLogical.AssertExpectations(t)
+	mockClient.AssertCalled(t, "SetToken", "token-test")
+}
+
+func TestStart_RenewFailedLogsInWithBackoff(t *testing.T) {
+	mockLogical := new(mocks.MockLogicalAPI)
+	mockAuth := new(mocks.MockAuthAPI)
+	mockToken := new(mocks.MockAuthTokenAPI)
+	mockClient := new(mocks.MockIVaultClient)
+
+	mockClient.On("Logical").Return(mockLogical)
+	mockClient.On("Auth").Return(mockAuth)
+	mockClient.On("SetToken", "token-test").Return()
+	mockAuth.On("Token").Return(mockToken)
+	mockToken.On("RenewSelfWithContext", mock.Anything, 3600).Return(nil, fmt.Errorf("connection refused")).Once()
+	loginOK := &vault.Secret{Auth: &vault.SecretAuth{ClientToken: "token-test", LeaseDuration: 3600, Renewable: true}}
+	mockLogical.On("Write", "auth/approle/login", mock.Anything).Return(loginOK, nil).Once()
+	mockLogical.On("Write", "auth/approle/login", mock.Anything).Return(nil, fmt.Errorf("connection refused")).Once()
+	loggedIn := make(chan struct{})
+	mockLogical.On("Write", "auth/approle/login", mock.Anything).Return(loginOK, nil).Once().
+		Run(func(mock.Arguments) { close(loggedIn) })
+
+	// The first renewal and the backoffs elapse at once, the later renewals never do
+	elapsed := func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+	mockSleeper := new(mocks.MockSleeper)
+	mockSleeper.On("After", 48*time.Minute).Return(elapsed).Once()
+	mockSleeper.On("After", time.Second).Return(elapsed)
+	mockSleeper.On("After", mock.Anything).Return(func(time.Duration) <-chan time.Time {
+		return make(chan time.Time)
+	})
+
+	c := client.NewVaultAuthClient(client.VaultSettings{
+		Auth:      client.AppRoleAuth{Path: "approle", RoleID: "role-id", SecretID: "secret-id"},
+		KVMount:   "secret",
+		KVVersion: 2,
+	}, mockClient)
+	c.SetSleeperHelper(mockSleeper)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() { done <- c.Start(ctx) }()
+
+	select {
+	case <-loggedIn:
+	case <-time.After(time.Second):
+		t.Fatal("failed login after a failed renewal not retried")
+	}
+	cancel()
+
+	require.NoError(t, <-done)
+	mockLogical.AssertExpectations(t)
+	mockToken.AssertExpectations(t)
+}
@@ -3,8 +3,11 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	webhookv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/internal/webhook/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -22,6 +25,42 @@ type MainConfig struct {
 	RoleSecret     string
 	ClientID       string
 	ClientSecret   string
+	// KVVersion is the version of the KV secrets engine mounted at KVMount, 1 or 2
+	KVVersion int
+	// KVPathTemplate is the path of the Vault secret of a tenant, e.g. aruba/{{tenant}}/api
+	KVPathTemplate string
+	// VaultAuthMethod is how the operator logs in to Vault: approle, kubernetes or token-file
+	VaultAuthMethod string
+	// KubernetesAuthPath, KubernetesAuthRole and KubernetesTokenFile configure the kubernetes auth method
+	KubernetesAuthPath  string
+	KubernetesAuthRole  string
+	KubernetesTokenFile string
+	// VaultTokenFile is the file holding the Vault token of the token-file auth method
+	VaultTokenFile string
+	// VaultSecretCacheTTL is how long the tenant credentials read from Vault are cached
+	VaultSecretCacheTTL time.Duration
+
+	// MaxConcurrentReconciles is the number of workers per controller
+	MaxConcurrentReconciles int
+	// DriftCheckInterval is how often created resources are compared with the remote system
+	DriftCheckInterval time.Duration
+	// AllowCrossNamespaceReferences lets resources reference resources in other namespaces
+	AllowCrossNamespaceReferences bool
+	// DefaultTenant, DefaultLocation and DefaultDataCenter are filled in by the defaulting webhook
+	// when neither the referenced Project nor the namespace provide them
+	DefaultTenant     string
+	DefaultLocation   string
+	DefaultDataCenter string
+	// APIRetryPolicy controls the retries of transient API failures
+	APIRetryPolicy arubaClient.RetryPolicy
+	// APIRateLimit is the token bucket applied to the API requests of each tenant
+	APIRateLimit arubaClient.RateLimit
+	// APICircuitBreaker controls when the API gateway is considered down
+	APICircuitBreaker arubaClient.CircuitBreakerSettings
+	// ReadinessCacheTTL is how long the result of a readiness check is reused before running it again
+	ReadinessCacheTTL time.Duration
+	// LogAPIBodies logs the bodies of the API requests and responses, off by default as they may carry sensitive data
+	LogAPIBodies bool
 }
 
 // Validate ensures all required fields are present.
@@ -46,10 +85,30 @@ func (c *MainConfig) Validate() error {
 			"vault-address": c.VaultAddress,
 			"keycloak-url":  c.KeycloakURL,
 			"realm-api":     c.RealmAPI,
-			"role-path":     c.RolePath,
 			"kv-mount":      c.KVMount,
-			"role-id":       c.RoleID,
-			"role-secret":   c.RoleSecret,
+		}
+		switch c.VaultAuthMethod {
+		case arubaClient.VaultAuthAppRole:
+			required["role-path"] = c.RolePath
+			required["role-id"] = c.RoleID
+			required["role-secret"] = c.RoleSecret
+		case arubaClient.VaultAuthKubernetes:
+			required["vault-kubernetes-path"] = c.KubernetesAuthPath
+			required["vault-kubernetes-role"] = c.KubernetesAuthRole
+			required["vault-kubernetes-token-file"] = c.KubernetesTokenFile
+		case arubaClient.VaultAuthTokenFile:
+			required["vault-token-file"] = c.VaultTokenFile
+		default:
+			return fmt.Errorf("invalid configuration value: vault-auth-method must be one of %s, %s, %s",
+				arubaClient.VaultAuthAppRole, arubaClient.VaultAuthKubernetes, arubaClient.VaultAuthTokenFile)
+		}
+
+		if c.KVVersion != 1 && c.KVVersion != 2 {
+			return fmt.Errorf("invalid configuration value: kv-version must be 1 or 2")
+		}
+
+		if !strings.Contains(c.KVPathTemplate, arubaClient.TenantPlaceholder) {
+			return fmt.Errorf("invalid configuration value: kv-path-template must contain %s", arubaClient.TenantPlaceholder)
 		}
 	}
 	ctrl.Log.V(1).Info("Validate configurations", "required", required)
@@ -59,6 +118,38 @@ func (c *MainConfig) Validate() error {
 			return fmt.Errorf("missing required configuration value: %s", key)
 		}
 	}
+
+	if c.MaxConcurrentReconciles < 1 {
+		return fmt.Errorf("invalid configuration value: max-concurrent-reconciles must be at least 1")
+	}
+
+	if c.DriftCheckInterval <= 0 {
+		return fmt.Errorf("invalid configuration value: drift-check-interval must be positive")
+	}
+
+	if c.APIRetryPolicy.MaxRetries < 0 {
+		return fmt.Errorf("invalid configuration value: api-max-retries must not be negative")
+	}
+
+	if c.APIRetryPolicy.InitialBackoff <= 0 || c.APIRetryPolicy.MaxBackoff < c.APIRetryPolicy.InitialBackoff {
+		return fmt.Errorf("invalid configuration value: api-retry-initial-backoff must be positive and not greater than api-retry-max-backoff")
+	}
+
+	if c.APIRateLimit.QPS < 0 || c.APIRateLimit.Burst < 1 {
+		return fmt.Errorf("invalid configuration value: api-rate-limit-qps must not be negative and api-rate-limit-burst must be at least 1")
+	}
+
+	if c.APICircuitBreaker.FailureThreshold < 1 || c.APICircuitBreaker.OpenTimeout <= 0 {
+		return fmt.Errorf("invalid configuration value: api-circuit-breaker-failure-threshold must be at least 1 and api-circuit-breaker-open-timeout positive")
+	}
+
+	if c.VaultSecretCacheTTL < 0 {
+		return fmt.Errorf("invalid configuration value: vault-secret-cache-ttl must not be negative")
+	}
+
+	if c.ReadinessCacheTTL < 0 {
+		return fmt.Errorf("invalid configuration value: readiness-cache-ttl must not be negative")
+	}
 	return nil
 }
 
@@ -77,5 +168,37 @@ func (c *MainConfig) ToReconcilerConfig() reconciler.ReconcilerConfig {
 		KVMount:        c.KVMount,
 		RoleID:         c.RoleID,
 		RoleSecret:     c.RoleSecret,
+		KVVersion:      c.KVVersion,
+		KVPathTemplate: c.KVPathTemplate,
+
+		VaultAuthMethod:     c.VaultAuthMethod,
+		KubernetesAuthPath:  c.KubernetesAuthPath,
+		KubernetesAuthRole:  c.KubernetesAuthRole,
+		KubernetesTokenFile: c.KubernetesTokenFile,
+		VaultTokenFile:      c.VaultTokenFile,
+		VaultSecretCacheTTL: c.VaultSecretCacheTTL,
+
+		MaxConcurrentReconciles: c.MaxConcurrentReconciles,
+		DriftCheckInterval:      c.DriftCheckInterval,
+		RetryPolicy:             c.APIRetryPolicy,
+		RateLimit:               c.APIRateLimit,
+		CircuitBreaker:          c.APICircuitBreaker,
+		LogAPIBodies:            c.LogAPIBodies,
+	}
+}
+
+// ToValidationOptions converts MainConfig into the options of the validating webhooks.
+func (c *MainConfig) ToValidationOptions() webhookv1alpha1.ValidationOptions {
+	return webhookv1alpha1.ValidationOptions{
+		AllowCrossNamespaceReferences: c.AllowCrossNamespaceReferences,
+	}
+}
+
+// ToDefaultingOptions converts MainConfig into the options of the defaulting webhooks.
+func (c *MainConfig) ToDefaultingOptions() webhookv1alpha1.DefaultingOptions {
+	return webhookv1alpha1.DefaultingOptions{
+		Tenant:     c.DefaultTenant,
+		Location:   c.DefaultLocation,
+		DataCenter: c.DefaultDataCenter,
 	}
 }
@@ -3,20 +3,41 @@ package config
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/health"
+)
+
+const (
+	// defaultMaxConcurrentReconciles is used when the ConfigMap does not set max-concurrent-reconciles
+	defaultMaxConcurrentReconciles = 1
+	// defaultDriftCheckInterval is used when the ConfigMap does not set drift-check-interval
+	defaultDriftCheckInterval = 10 * time.Minute
+	// defaultAPIRateLimitQPS and defaultAPIRateLimitBurst are used when the ConfigMap does not set
+	// api-rate-limit-qps and api-rate-limit-burst
+	defaultAPIRateLimitQPS   = 10
+	defaultAPIRateLimitBurst = 20
+	// defaultKVVersion is used when the ConfigMap does not set kv-version
+	defaultKVVersion = 2
+	// defaultKubernetesAuthPath is used when the ConfigMap does not set vault-kubernetes-path
+	defaultKubernetesAuthPath = "kubernetes"
 )
 
 // Load reads the operator configuration from ConfigMap and Secret.
+// The API server is read directly, as the manager cache is not started yet.
 func Load(ctx context.Context, mgr ctrl.Manager, configMapName, configNamespace, secretName string) (*MainConfig, error) {
-	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: mgr.GetScheme()})
-	if err != nil {
-		return nil, fmt.Errorf("unable to create k8s client: %w", err)
-	}
+	return read(ctx, mgr.GetAPIReader(), configMapName, configNamespace, secretName)
+}
 
+// read reads the ConfigMap and Secret of the operator and parses them
+func read(ctx context.Context, c client.Reader, configMapName, configNamespace, secretName string) (*MainConfig, error) {
 	cfg, err := getConfigMap(ctx, c, configNamespace, configMapName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read configmap %s: %w", configMapName, err)
@@ -27,19 +48,134 @@ func Load(ctx context.Context, mgr ctrl.Manager, configMapName, configNamespace,
 		return nil, fmt.Errorf("failed to read secret %s: %w", secretName, err)
 	}
 
+	return Parse(cfg, secret)
+}
+
+// Parse builds the operator configuration from the data of its ConfigMap and Secret and validates it.
+func Parse(cfg *corev1.ConfigMap, secret *corev1.Secret) (*MainConfig, error) {
+	var err error
+	maxConcurrentReconciles := defaultMaxConcurrentReconciles
+	if val, ok := cfg.Data["max-concurrent-reconciles"]; ok && val != "" {
+		maxConcurrentReconciles, err = strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max-concurrent-reconciles %q: %w", val, err)
+		}
+	}
+
+	driftCheckInterval := defaultDriftCheckInterval
+	if val, ok := cfg.Data["drift-check-interval"]; ok && val != "" {
+		driftCheckInterval, err = time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid drift-check-interval %q: %w", val, err)
+		}
+	}
+
+	retryPolicy := arubaClient.DefaultRetryPolicy()
+	if val, ok := cfg.Data["api-max-retries"]; ok && val != "" {
+		retryPolicy.MaxRetries, err = strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api-max-retries %q: %w", val, err)
+		}
+	}
+	if val, ok := cfg.Data["api-retry-initial-backoff"]; ok && val != "" {
+		retryPolicy.InitialBackoff, err = time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api-retry-initial-backoff %q: %w", val, err)
+		}
+	}
+	if val, ok := cfg.Data["api-retry-max-backoff"]; ok && val != "" {
+		retryPolicy.MaxBackoff, err = time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api-retry-max-backoff %q: %w", val, err)
+		}
+	}
+
+	rateLimit := arubaClient.RateLimit{QPS: defaultAPIRateLimitQPS, Burst: defaultAPIRateLimitBurst}
+	if val, ok := cfg.Data["api-rate-limit-qps"]; ok && val != "" {
+		rateLimit.QPS, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api-rate-limit-qps %q: %w", val, err)
+		}
+	}
+	if val, ok := cfg.Data["api-rate-limit-burst"]; ok && val != "" {
+		rateLimit.Burst, err = strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api-rate-limit-burst %q: %w", val, err)
+		}
+	}
+
+	circuitBreaker := arubaClient.DefaultCircuitBreakerSettings()
+	if val, ok := cfg.Data["api-circuit-breaker-failure-threshold"]; ok && val != "" {
+		circuitBreaker.FailureThreshold, err = strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api-circuit-breaker-failure-threshold %q: %w", val, err)
+		}
+	}
+	if val, ok := cfg.Data["api-circuit-breaker-open-timeout"]; ok && val != "" {
+		circuitBreaker.OpenTimeout, err = time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid api-circuit-breaker-open-timeout %q: %w", val, err)
+		}
+	}
+
+	readinessCacheTTL := health.DefaultCacheTTL
+	if val, ok := cfg.Data["readiness-cache-ttl"]; ok && val != "" {
+		readinessCacheTTL, err = time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid readiness-cache-ttl %q: %w", val, err)
+		}
+	}
+
+	vaultSecretCacheTTL := arubaClient.DefaultSecretCacheTTL
+	if val, ok := cfg.Data["vault-secret-cache-ttl"]; ok && val != "" {
+		vaultSecretCacheTTL, err = time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vault-secret-cache-ttl %q: %w", val, err)
+		}
+	}
+
+	kvVersion := defaultKVVersion
+	if val, ok := cfg.Data["kv-version"]; ok && val != "" {
+		kvVersion, err = strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kv-version %q: %w", val, err)
+		}
+	}
+
 	mainConfig := &MainConfig{
-		APIGateway:     cfg.Data["api-gateway"],
-		VaultIsEnabled: cfg.Data["vault-enabled"] == "true",
-		VaultAddress:   cfg.Data["vault-address"],
-		KeycloakURL:    cfg.Data["keycloak-url"],
-		RealmAPI:       cfg.Data["realm-api"],
-		Namespace:      cfg.Data["role-namespace"],
-		RolePath:       cfg.Data["role-path"],
-		KVMount:        cfg.Data["kv-mount"],
-		RoleID:         string(secret.Data["role-id"]),
-		RoleSecret:     string(secret.Data["role-secret"]),
-		ClientID:       string(secret.Data["client-id"]),
-		ClientSecret:   string(secret.Data["client-secret"]),
+		APIGateway:          cfg.Data["api-gateway"],
+		VaultIsEnabled:      cfg.Data["vault-enabled"] == "true",
+		VaultAddress:        cfg.Data["vault-address"],
+		KeycloakURL:         cfg.Data["keycloak-url"],
+		RealmAPI:            cfg.Data["realm-api"],
+		Namespace:           cfg.Data["role-namespace"],
+		RolePath:            cfg.Data["role-path"],
+		KVMount:             cfg.Data["kv-mount"],
+		KVVersion:           kvVersion,
+		KVPathTemplate:      valueOrDefault(cfg.Data, "kv-path-template", arubaClient.DefaultPathTemplate),
+		VaultAuthMethod:     valueOrDefault(cfg.Data, "vault-auth-method", arubaClient.VaultAuthAppRole),
+		KubernetesAuthPath:  valueOrDefault(cfg.Data, "vault-kubernetes-path", defaultKubernetesAuthPath),
+		KubernetesAuthRole:  cfg.Data["vault-kubernetes-role"],
+		KubernetesTokenFile: valueOrDefault(cfg.Data, "vault-kubernetes-token-file", arubaClient.DefaultKubernetesTokenFile),
+		VaultTokenFile:      cfg.Data["vault-token-file"],
+		VaultSecretCacheTTL: vaultSecretCacheTTL,
+		RoleID:              string(secret.Data["role-id"]),
+		RoleSecret:          string(secret.Data["role-secret"]),
+		ClientID:            string(secret.Data["client-id"]),
+		ClientSecret:        string(secret.Data["client-secret"]),
+
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		DriftCheckInterval:      driftCheckInterval,
+
+		AllowCrossNamespaceReferences: cfg.Data["allow-cross-namespace-references"] == "true",
+		DefaultTenant:                 cfg.Data["default-tenant"],
+		DefaultLocation:               cfg.Data["default-location"],
+		DefaultDataCenter:             cfg.Data["default-data-center"],
+		APIRetryPolicy:                retryPolicy,
+		APIRateLimit:                  rateLimit,
+		APICircuitBreaker:             circuitBreaker,
+		ReadinessCacheTTL:             readinessCacheTTL,
+		LogAPIBodies:                  cfg.Data["log-api-bodies"] == "true",
 	}
 
 	if err := mainConfig.Validate(); err != nil {
@@ -49,7 +185,15 @@ func Load(ctx context.Context, mgr ctrl.Manager, configMapName, configNamespace,
 	return mainConfig, nil
 }
 
-func getConfigMap(ctx context.Context, c client.Client, ns, name string) (*corev1.ConfigMap, error) {
+// valueOrDefault returns the value of key in data, or def when it is missing or empty
+func valueOrDefault(data map[string]string, key, def string) string {
+	if val := data[key]; val != "" {
+		return val
+	}
+	return def
+}
+
+func getConfigMap(ctx context.Context, c client.Reader, ns, name string) (*corev1.ConfigMap, error) {
 	cfg := &corev1.ConfigMap{}
 	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: ns}, cfg); err != nil {
 		return nil, err
@@ -57,7 +201,7 @@ func getConfigMap(ctx context.Context, c client.Client, ns, name string) (*corev
 	return cfg, nil
 }
 
-func getSecret(ctx context.Context, c client.Client, ns, name string) (*corev1.Secret, error) {
+func getSecret(ctx context.Context, c client.Reader, ns, name string) (*corev1.Secret, error) {
 	secret := &corev1.Secret{}
 	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: ns}, secret); err != nil {
 		return nil, err
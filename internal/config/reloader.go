@@ -0,0 +1,183 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/metrics"
+)
+
+// Reasons of the events emitted on the operator ConfigMap when the configuration is reloaded
+const (
+	ReasonConfigLoaded    = "ConfigLoaded"
+	ReasonConfigInvalid   = "ConfigInvalid"
+	ReasonRestartRequired = "RestartRequired"
+)
+
+// ApplyFunc applies a validated configuration to the running operator
+type ApplyFunc func(cfg *MainConfig) error
+
+// Reloader watches the ConfigMap and Secret of the operator and applies their changes without
+// a restart. A change is parsed and validated first: an invalid configuration is reported and
+// the previous one stays in effect.
+type Reloader struct {
+	client.Reader
+	Recorder      record.EventRecorder
+	ConfigMapName string
+	SecretName    string
+	Namespace     string
+	apply         ApplyFunc
+
+	mu      sync.Mutex
+	current *MainConfig
+}
+
+// NewReloader creates a Reloader of the configuration loaded at startup. The reader must
+// serve the objects of the configuration namespace, SetupWithManager sets one up otherwise.
+func NewReloader(reader client.Reader, recorder record.EventRecorder, configMapName, namespace, secretName string, current *MainConfig, apply ApplyFunc) *Reloader {
+	return &Reloader{
+		Reader:        reader,
+		Recorder:      recorder,
+		ConfigMapName: configMapName,
+		SecretName:    secretName,
+		Namespace:     namespace,
+		apply:         apply,
+		current:       current,
+	}
+}
+
+// Reconcile reads the configuration and applies it when it changed
+func (r *Reloader) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.Log.WithValues("ConfigMap", r.ConfigMapName, "Secret", r.SecretName, "Namespace", r.Namespace)
+
+	configMap, err := getConfigMap(ctx, r, r.Namespace, r.ConfigMapName)
+	if err != nil {
+		r.rejected(nil, fmt.Errorf("failed to read configmap %s: %w", r.ConfigMapName, err))
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	secret, err := getSecret(ctx, r, r.Namespace, r.SecretName)
+	if err != nil {
+		r.rejected(configMap, fmt.Errorf("failed to read secret %s: %w", r.SecretName, err))
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	cfg, err := Parse(configMap, secret)
+	if err != nil {
+		// The configuration is only fixed by another change, which triggers a new reconcile
+		r.rejected(configMap, err)
+		return ctrl.Result{}, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current != nil && *r.current == *cfg {
+		metrics.ConfigLoaded.Set(1)
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.apply(cfg); err != nil {
+		r.rejected(configMap, err)
+		return ctrl.Result{}, err
+	}
+	if r.current != nil {
+		if keys := restartKeys(r.current, cfg); len(keys) > 0 {
+			logger.Info("Configuration changes need a restart to take effect", "keys", keys)
+			r.Recorder.Eventf(configMap, corev1.EventTypeWarning, ReasonRestartRequired,
+				"Changes to %s take effect after a restart", strings.Join(keys, ", "))
+		}
+	}
+	r.current = cfg
+
+	logger.Info("Configuration reloaded")
+	metrics.ConfigLoaded.Set(1)
+	metrics.ConfigReloads.WithLabelValues(metrics.ResultSuccess).Inc()
+	r.Recorder.Event(configMap, corev1.EventTypeNormal, ReasonConfigLoaded, "Configuration reloaded")
+	return ctrl.Result{}, nil
+}
+
+// rejected reports a configuration that could not be loaded, on configMap when it exists
+func (r *Reloader) rejected(configMap *corev1.ConfigMap, err error) {
+	ctrl.Log.Error(err, "Configuration not reloaded, the previous one stays in effect")
+	metrics.ConfigLoaded.Set(0)
+	metrics.ConfigReloads.WithLabelValues(metrics.ResultError).Inc()
+	if configMap != nil {
+		r.Recorder.Eventf(configMap, corev1.EventTypeWarning, ReasonConfigInvalid,
+			"Configuration not reloaded: %v", err)
+	}
+}
+
+// restartKeys returns the keys changed from old to cfg that are only read at startup
+func restartKeys(old, cfg *MainConfig) []string {
+	var keys []string
+	changed := func(key string, before, after any) {
+		if before != after {
+			keys = append(keys, key)
+		}
+	}
+	changed("vault-enabled", old.VaultIsEnabled, cfg.VaultIsEnabled)
+	changed("max-concurrent-reconciles", old.MaxConcurrentReconciles, cfg.MaxConcurrentReconciles)
+	changed("drift-check-interval", old.DriftCheckInterval, cfg.DriftCheckInterval)
+	changed("allow-cross-namespace-references", old.AllowCrossNamespaceReferences, cfg.AllowCrossNamespaceReferences)
+	changed("default-tenant", old.DefaultTenant, cfg.DefaultTenant)
+	changed("default-location", old.DefaultLocation, cfg.DefaultLocation)
+	changed("default-data-center", old.DefaultDataCenter, cfg.DefaultDataCenter)
+	changed("api-max-retries", old.APIRetryPolicy.MaxRetries, cfg.APIRetryPolicy.MaxRetries)
+	changed("api-retry-initial-backoff", old.APIRetryPolicy.InitialBackoff, cfg.APIRetryPolicy.InitialBackoff)
+	changed("api-retry-max-backoff", old.APIRetryPolicy.MaxBackoff, cfg.APIRetryPolicy.MaxBackoff)
+	changed("api-rate-limit-qps", old.APIRateLimit.QPS, cfg.APIRateLimit.QPS)
+	changed("api-rate-limit-burst", old.APIRateLimit.Burst, cfg.APIRateLimit.Burst)
+	changed("api-circuit-breaker-failure-threshold", old.APICircuitBreaker.FailureThreshold, cfg.APICircuitBreaker.FailureThreshold)
+	changed("api-circuit-breaker-open-timeout", old.APICircuitBreaker.OpenTimeout, cfg.APICircuitBreaker.OpenTimeout)
+	changed("readiness-cache-ttl", old.ReadinessCacheTTL, cfg.ReadinessCacheTTL)
+	changed("log-api-bodies", old.LogAPIBodies, cfg.LogAPIBodies)
+	return keys
+}
+
+// SetupWithManager sets up the controller with the Manager. The ConfigMap and Secret are
+// watched through a cache restricted to the configuration namespace, so the Secrets of the
+// other namespaces are not cached. Every replica reloads its own configuration.
+func (r *Reloader) SetupWithManager(mgr ctrl.Manager) error {
+	namespaced, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:            mgr.GetScheme(),
+		Mapper:            mgr.GetRESTMapper(),
+		DefaultNamespaces: map[string]cache.Config{r.Namespace: {}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the configuration cache: %w", err)
+	}
+	if err := mgr.Add(namespaced); err != nil {
+		return fmt.Errorf("failed to add the configuration cache to manager: %w", err)
+	}
+	r.Reader = namespaced
+
+	// The ConfigMap and the Secret make a single configuration, reconciled under the ConfigMap name
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: r.Namespace, Name: r.ConfigMapName}}}
+	})
+	named := func(name string) predicate.Predicate {
+		return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == name
+		})
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WatchesRawSource(source.Kind[client.Object](namespaced, &corev1.ConfigMap{}, enqueue, named(r.ConfigMapName))).
+		WatchesRawSource(source.Kind[client.Object](namespaced, &corev1.Secret{}, enqueue, named(r.SecretName))).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Named("config").
+		Complete(r)
+}
@@ -0,0 +1,139 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/config"
+)
+
+func newConfig() (*corev1.ConfigMap, *corev1.Secret) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "aruba-controller-manager", Namespace: "aruba-system"},
+		Data: map[string]string{
+			"api-gateway":  "https://api.example.com",
+			"keycloak-url": "https://login.example.com",
+			"realm-api":    "realm",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "aruba-controller-manager", Namespace: "aruba-system"},
+		Data: map[string][]byte{
+			"client-id":     []byte("client-id"),
+			"client-secret": []byte("client-secret"),
+		},
+	}
+	return configMap, secret
+}
+
+func TestReloader_Reconcile(t *testing.T) {
+	configMap, secret := newConfig()
+	current, err := config.Parse(configMap, secret)
+	require.NoError(t, err)
+
+	secret.Data["client-secret"] = []byte("rotated-secret")
+	configMap.Data["drift-check-interval"] = "1h"
+	reader := fake.NewClientBuilder().WithObjects(configMap, secret).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	var applied []*config.MainConfig
+	reloader := config.NewReloader(reader, recorder, "aruba-controller-manager", "aruba-system", "aruba-controller-manager", current,
+		func(cfg *config.MainConfig) error {
+			applied = append(applied, cfg)
+			return nil
+		})
+
+	_, err = reloader.Reconcile(t.Context(), ctrl.Request{})
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	assert.Equal(t, "rotated-secret", applied[0].ClientSecret)
+	assert.Equal(t, "Warning RestartRequired Changes to drift-check-interval take effect after a restart", <-recorder.Events)
+	assert.Equal(t, "Normal ConfigLoaded Configuration reloaded", <-recorder.Events)
+
+	// An unchanged configuration is not applied again
+	_, err = reloader.Reconcile(t.Context(), ctrl.Request{})
+	require.NoError(t, err)
+	assert.Len(t, applied, 1)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestReloader_Reconcile_Invalid(t *testing.T) {
+	configMap, secret := newConfig()
+	current, err := config.Parse(configMap, secret)
+	require.NoError(t, err)
+
+	delete(configMap.Data, "api-gateway")
+	reader := fake.NewClientBuilder().WithObjects(configMap, secret).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	reloader := config.NewReloader(reader, recorder, "aruba-controller-manager", "aruba-system", "aruba-controller-manager", current,
+		func(*config.MainConfig) error {
+			t.Fatal("an invalid configuration must not be applied")
+			return nil
+		})
+
+	_, err = reloader.Reconcile(t.Context(), ctrl.Request{})
+	require.NoError(t, err)
+	assert.Contains(t, <-recorder.Events, "Warning ConfigInvalid Configuration not reloaded")
+}
+
+func TestReloader_Reconcile_ApplyFailed(t *testing.T) {
+	configMap, secret := newConfig()
+	current, err := config.Parse(configMap, secret)
+	require.NoError(t, err)
+
+	configMap.Data["api-gateway"] = "https://api2.example.com"
+	reader := fake.NewClientBuilder().WithObjects(configMap, secret).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	applyErr := errors.New("vault login failed")
+	var applied []*config.MainConfig
+	reloader := config.NewReloader(reader, recorder, "aruba-controller-manager", "aruba-system", "aruba-controller-manager", current,
+		func(cfg *config.MainConfig) error {
+			applied = append(applied, cfg)
+			return applyErr
+		})
+
+	_, err = reloader.Reconcile(t.Context(), ctrl.Request{})
+	require.ErrorIs(t, err, applyErr)
+	assert.Contains(t, <-recorder.Events, "Warning ConfigInvalid Configuration not reloaded")
+
+	// The configuration was not recorded as current, so the retry applies it again
+	applyErr = nil
+	_, err = reloader.Reconcile(t.Context(), ctrl.Request{})
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	assert.Equal(t, "https://api2.example.com", applied[1].APIGateway)
+	assert.Equal(t, "Normal ConfigLoaded Configuration reloaded", <-recorder.Events)
+}
+
+func TestReloader_Reconcile_VaultEnabledNeedsRestart(t *testing.T) {
+	configMap, secret := newConfig()
+	current, err := config.Parse(configMap, secret)
+	require.NoError(t, err)
+
+	configMap.Data["vault-enabled"] = "true"
+	configMap.Data["vault-address"] = "https://vault.example.com"
+	configMap.Data["kv-mount"] = "kv"
+	configMap.Data["role-path"] = "approle"
+	secret.Data["role-id"] = []byte("role-id")
+	secret.Data["role-secret"] = []byte("role-secret")
+	reader := fake.NewClientBuilder().WithObjects(configMap, secret).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	reloader := config.NewReloader(reader, recorder, "aruba-controller-manager", "aruba-system", "aruba-controller-manager", current,
+		func(*config.MainConfig) error { return nil })
+
+	_, err = reloader.Reconcile(t.Context(), ctrl.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, "Warning RestartRequired Changes to vault-enabled take effect after a restart", <-recorder.Events)
+	assert.Equal(t, "Normal ConfigLoaded Configuration reloaded", <-recorder.Events)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
 )
 
 // BlockStorageReconciler reconciles a BlockStorage object
@@ -53,9 +54,19 @@ func (r *BlockStorageReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BlockStorageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.BlockStorage{}, reconciler.ProjectReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.BlockStorage).Spec.ProjectReference}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.BlockStorage{}).
+		Watches(&v1alpha1.Project{}, r.EnqueueReferencing(&v1alpha1.BlockStorageList{}, reconciler.ProjectReferenceIndex), reconciler.ReferencedStatusChanged()).
 		Named("blockstorage").
+		WithOptions(r.ControllerOptions()).
 		Complete(r)
 }
 
@@ -67,6 +78,14 @@ func (r *BlockStorageReconciler) Init(ctx context.Context, obj client.Object, st
 	return r.InitializeResource(ctx, obj, status, blockStorageFinalizerName)
 }
 
+// Dependencies returns the resources that must be provisioned before the BlockStorage can be created or updated
+func (r *BlockStorageReconciler) Dependencies(obj client.Object) []reconciler.Dependency {
+	blockStorage := obj.(*v1alpha1.BlockStorage)
+	return []reconciler.Dependency{
+		{Kind: "Project", Reference: blockStorage.Spec.ProjectReference},
+	}
+}
+
 func (r *BlockStorageReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	blockStorage := obj.(*v1alpha1.BlockStorage)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
@@ -78,7 +97,7 @@ func (r *BlockStorageReconciler) Creating(ctx context.Context, obj client.Object
 		blockStorageReq := arubaClient.BlockStorageRequest{
 			Metadata: arubaClient.BlockStorageMetadata{
 				Name: blockStorage.Name,
-				Tags: blockStorage.Spec.Tags,
+				Tags: reconciler.OwnedTags(blockStorage, blockStorage.Spec.Tags),
 				Location: arubaClient.BlockStorageLocation{
 					Value: blockStorage.Spec.Location.Value,
 				},
@@ -93,10 +112,20 @@ func (r *BlockStorageReconciler) Creating(ctx context.Context, obj client.Object
 			},
 		}
 
-		blockStorageResp, err := r.CreateBlockStorage(ctx, projectID, blockStorageReq)
+		// Adopt a BlockStorage created by a previous attempt whose ID was never recorded
+		blockStorageList, err := r.ListBlockStorages(ctx, projectID)
 		if err != nil {
 			return "", "", err
 		}
+		blockStorageResp := reconciler.FindOwned(obj, blockStorageList.Values, func(remote *arubaClient.BlockStorageResponse) []string {
+			return remote.Metadata.Tags
+		})
+		if blockStorageResp == nil {
+			blockStorageResp, err = r.CreateBlockStorage(ctx, projectID, blockStorageReq)
+			if err != nil {
+				return "", "", err
+			}
+		}
 
 		blockStorage.Status.ProjectID = projectID
 
@@ -109,6 +138,23 @@ func (r *BlockStorageReconciler) Creating(ctx context.Context, obj client.Object
 	})
 }
 
+// Import adopts an existing remote BlockStorage and records the IDs of its parent resources
+func (r *BlockStorageReconciler) Import(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, resourceID string) error {
+	blockStorage := obj.(*v1alpha1.BlockStorage)
+	projectID, err := r.GetProjectID(ctx, blockStorage.Spec.ProjectReference.Name, blockStorage.Spec.ProjectReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.GetBlockStorage(ctx, projectID, resourceID)
+	if err != nil {
+		return err
+	}
+
+	blockStorage.Status.ProjectID = projectID
+	return nil
+}
+
 func (r *BlockStorageReconciler) Provisioning(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	blockStorage := obj.(*v1alpha1.BlockStorage)
 	return r.HandleProvisioning(ctx, obj, status, func(ctx context.Context) (string, error) {
@@ -130,7 +176,7 @@ func (r *BlockStorageReconciler) Updating(ctx context.Context, obj client.Object
 		blockStorageReq := arubaClient.BlockStorageRequest{
 			Metadata: arubaClient.BlockStorageMetadata{
 				Name: blockStorage.Name,
-				Tags: blockStorage.Spec.Tags,
+				Tags: reconciler.OwnedTags(blockStorage, blockStorage.Spec.Tags),
 				Location: arubaClient.BlockStorageLocation{
 					Value: blockStorage.Spec.Location.Value,
 				},
@@ -148,12 +194,33 @@ func (r *BlockStorageReconciler) Updating(ctx context.Context, obj client.Object
 }
 
 func (r *BlockStorageReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
-	return r.CheckForUpdates(ctx, obj, status)
+	blockStorage := obj.(*v1alpha1.BlockStorage)
+	return r.HandleCreated(ctx, obj, status, func(ctx context.Context) ([]string, error) {
+		blockStorageResp, err := r.GetBlockStorage(ctx, blockStorage.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		var driftedFields []string
+		if !util.SameElements(blockStorage.Spec.Tags, reconciler.WithoutOwnershipTag(blockStorageResp.Metadata.Tags)) {
+			driftedFields = append(driftedFields, "spec.tags")
+		}
+		if blockStorage.Spec.SizeGb != blockStorageResp.Properties.SizeGb {
+			driftedFields = append(driftedFields, "spec.sizeGb")
+		}
+		if blockStorage.Spec.BillingPeriod != blockStorageResp.Properties.BillingPeriod {
+			driftedFields = append(driftedFields, "spec.billingPeriod")
+		}
+		return driftedFields, nil
+	})
 }
 
 func (r *BlockStorageReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	blockStorage := obj.(*v1alpha1.BlockStorage)
 	return r.HandleDeletion(ctx, obj, status, blockStorageFinalizerName, func(ctx context.Context) error {
 		return r.DeleteBlockStorage(ctx, blockStorage.Status.ProjectID, status.ResourceID)
+	}, func(ctx context.Context) error {
+		_, err := r.GetBlockStorage(ctx, blockStorage.Status.ProjectID, status.ResourceID)
+		return err
 	})
 }
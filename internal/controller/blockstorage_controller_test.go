@@ -29,6 +29,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -380,6 +381,65 @@ var _ = Describe("BlockStorage Controller Reconcile Method", func() {
 			Expect(k8sClient.Delete(ctx, arubaBlockStorage)).To(Succeed())
 		})
 
+		It("should report drift of the remote block storage in the Created phase", func() {
+			By("Creating resource")
+			testName := fmt.Sprintf("test-drift-bs-%d", GinkgoRandomSeed())
+			namespacedName := types.NamespacedName{
+				Name:      testName,
+				Namespace: "default",
+			}
+			arubaBlockStorage = &v1alpha1.BlockStorage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: "default",
+				},
+				Spec: v1alpha1.BlockStorageSpec{
+					Tenant: "test-tenant",
+					Tags:   []string{"test", "drift"},
+					Location: v1alpha1.Location{
+						Value: "ITBG-Bergamo",
+					},
+					SizeGb:        10,
+					BillingPeriod: "Hour",
+					DataCenter:    "ITBG-1",
+					ProjectReference: v1alpha1.ResourceReference{
+						Name:      "test-project",
+						Namespace: "default",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, arubaBlockStorage)).To(Succeed())
+
+			By("Moving the resource to the Created phase")
+			arubaBlockStorage.Status.ResourceStatus = v1alpha1.ResourceStatus{
+				Phase:              v1alpha1.ResourcePhaseCreated,
+				ResourceID:         "bs-123",
+				ObservedGeneration: arubaBlockStorage.Generation,
+			}
+			arubaBlockStorage.Status.ProjectID = "project-123"
+			Expect(k8sClient.Status().Update(ctx, arubaBlockStorage)).To(Succeed())
+
+			By("Reconciling the resource against a remote block storage without tags")
+			result, err := resourceReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: namespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			By("Verifying the Drifted condition is set and the phase is unchanged")
+			updatedBlockStorage := &v1alpha1.BlockStorage{}
+			Expect(k8sClient.Get(ctx, namespacedName, updatedBlockStorage)).To(Succeed())
+			Expect(updatedBlockStorage.Status.Phase).To(Equal(v1alpha1.ResourcePhaseCreated))
+			drifted := meta.FindStatusCondition(updatedBlockStorage.Status.Conditions, v1alpha1.ConditionTypeDrifted)
+			Expect(drifted).NotTo(BeNil())
+			Expect(drifted.Status).To(Equal(metav1.ConditionTrue))
+			Expect(drifted.Message).To(ContainSubstring("spec.tags"))
+			Expect(drifted.Message).To(ContainSubstring("spec.sizeGb"))
+
+			By("Cleanup")
+			Expect(k8sClient.Delete(ctx, updatedBlockStorage)).To(Succeed())
+		})
+
 		It("should test getProjectID method with valid project reference", func() {
 			By("Creating a test Project first")
 			projectName := fmt.Sprintf("test-ref-project-%d-%d", GinkgoRandomSeed(), GinkgoParallelProcess())
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,6 +35,12 @@ import (
 // +kubebuilder:rbac:groups=arubacloud.com,resources=cloudservers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=arubacloud.com,resources=cloudservers/finalizers,verbs=update
 // +kubebuilder:rbac:groups=arubacloud.com,resources=projects,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=vpcs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=subnets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=securitygroups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=elasticips,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=keypairs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=blockstorages,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
@@ -56,9 +63,66 @@ func (r *CloudServerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *CloudServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.CloudServer{}, reconciler.ProjectReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.CloudServer).Spec.ProjectReference}
+	}); err != nil {
+		return err
+	}
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.CloudServer{}, reconciler.VpcReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.CloudServer).Spec.VpcReference}
+	}); err != nil {
+		return err
+	}
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.CloudServer{}, reconciler.SubnetReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return obj.(*v1alpha1.CloudServer).Spec.SubnetReferences
+	}); err != nil {
+		return err
+	}
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.CloudServer{}, reconciler.SecurityGroupReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return obj.(*v1alpha1.CloudServer).Spec.SecurityGroupReferences
+	}); err != nil {
+		return err
+	}
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.CloudServer{}, reconciler.ElasticIpReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		cloudServer := obj.(*v1alpha1.CloudServer)
+		if cloudServer.Spec.ElasticIpReference == nil {
+			return nil
+		}
+		return []v1alpha1.ResourceReference{*cloudServer.Spec.ElasticIpReference}
+	}); err != nil {
+		return err
+	}
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.CloudServer{}, reconciler.KeyPairReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.CloudServer).Spec.KeyPairReference}
+	}); err != nil {
+		return err
+	}
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.CloudServer{}, reconciler.BlockStorageReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		cloudServer := obj.(*v1alpha1.CloudServer)
+		return append([]v1alpha1.ResourceReference{cloudServer.Spec.BootVolumeReference}, cloudServer.Spec.DataVolumeReferences...)
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.CloudServer{}).
+		Watches(&v1alpha1.Project{}, r.EnqueueReferencing(&v1alpha1.CloudServerList{}, reconciler.ProjectReferenceIndex), reconciler.ReferencedStatusChanged()).
+		Watches(&v1alpha1.Vpc{}, r.EnqueueReferencing(&v1alpha1.CloudServerList{}, reconciler.VpcReferenceIndex), reconciler.ReferencedStatusChanged()).
+		Watches(&v1alpha1.Subnet{}, r.EnqueueReferencing(&v1alpha1.CloudServerList{}, reconciler.SubnetReferenceIndex), reconciler.ReferencedStatusChanged()).
+		Watches(&v1alpha1.SecurityGroup{}, r.EnqueueReferencing(&v1alpha1.CloudServerList{}, reconciler.SecurityGroupReferenceIndex), reconciler.ReferencedStatusChanged()).
+		Watches(&v1alpha1.ElasticIp{}, r.EnqueueReferencing(&v1alpha1.CloudServerList{}, reconciler.ElasticIpReferenceIndex), reconciler.ReferencedStatusChanged()).
+		Watches(&v1alpha1.KeyPair{}, r.EnqueueReferencing(&v1alpha1.CloudServerList{}, reconciler.KeyPairReferenceIndex), reconciler.ReferencedStatusChanged()).
+		Watches(&v1alpha1.BlockStorage{}, r.EnqueueReferencing(&v1alpha1.CloudServerList{}, reconciler.BlockStorageReferenceIndex), reconciler.ReferencedStatusChanged()).
 		Named("cloudserver").
+		WithOptions(r.ControllerOptions()).
 		Complete(r)
 }
 
@@ -70,6 +134,32 @@ func (r *CloudServerReconciler) Init(ctx context.Context, obj client.Object, sta
 	return r.InitializeResource(ctx, obj, status, cloudServerFinalizerName)
 }
 
+// Dependencies returns the resources that must be provisioned before the CloudServer can be created or updated
+func (r *CloudServerReconciler) Dependencies(obj client.Object) []reconciler.Dependency {
+	cloudServer := obj.(*v1alpha1.CloudServer)
+	dependencies := []reconciler.Dependency{
+		{Kind: "Project", Reference: cloudServer.Spec.ProjectReference},
+		{Kind: "Vpc", Reference: cloudServer.Spec.VpcReference},
+		{Kind: "KeyPair", Reference: cloudServer.Spec.KeyPairReference},
+		{Kind: "BlockStorage", Reference: cloudServer.Spec.BootVolumeReference},
+	}
+
+	if cloudServer.Spec.ElasticIpReference != nil {
+		dependencies = append(dependencies, reconciler.Dependency{Kind: "ElasticIp", Reference: *cloudServer.Spec.ElasticIpReference})
+	}
+	for _, subnetRef := range cloudServer.Spec.SubnetReferences {
+		dependencies = append(dependencies, reconciler.Dependency{Kind: "Subnet", Reference: subnetRef})
+	}
+	for _, sgRef := range cloudServer.Spec.SecurityGroupReferences {
+		dependencies = append(dependencies, reconciler.Dependency{Kind: "SecurityGroup", Reference: sgRef})
+	}
+	for _, volumeRef := range cloudServer.Spec.DataVolumeReferences {
+		dependencies = append(dependencies, reconciler.Dependency{Kind: "BlockStorage", Reference: volumeRef})
+	}
+
+	return dependencies
+}
+
 func (r *CloudServerReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	cloudServer := obj.(*v1alpha1.CloudServer)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
@@ -117,7 +207,7 @@ func (r *CloudServerReconciler) Creating(ctx context.Context, obj client.Object,
 		cloudServerReq := arubaClient.CloudServerRequest{
 			Metadata: arubaClient.CloudServerMetadata{
 				Name: cloudServer.Name,
-				Tags: cloudServer.Spec.Tags,
+				Tags: reconciler.OwnedTags(cloudServer, cloudServer.Spec.Tags),
 				Location: arubaClient.CloudServerLocation{
 					Value: cloudServer.Spec.Location.Value,
 				},
@@ -154,10 +244,20 @@ func (r *CloudServerReconciler) Creating(ctx context.Context, obj client.Object,
 				arubaClient.CloudServerResourceReference{URI: r.buildSecurityGroupURI(projectID, vpcID, sgID)})
 		}
 
-		cloudServerResp, err := r.CreateCloudServer(ctx, projectID, cloudServerReq)
+		// Adopt a CloudServer created by a previous attempt whose ID was never recorded
+		cloudServerList, err := r.ListCloudServers(ctx, projectID)
 		if err != nil {
 			return "", "", err
 		}
+		cloudServerResp := reconciler.FindOwned(obj, cloudServerList.Values, func(remote *arubaClient.CloudServerResponse) []string {
+			return remote.Metadata.Tags
+		})
+		if cloudServerResp == nil {
+			cloudServerResp, err = r.CreateCloudServer(ctx, projectID, cloudServerReq)
+			if err != nil {
+				return "", "", err
+			}
+		}
 
 		// Update status with cloud server ID and all resolved IDs
 		cloudServer.Status.ProjectID = projectID
@@ -199,78 +299,20 @@ func (r *CloudServerReconciler) Provisioning(ctx context.Context, obj client.Obj
 func (r *CloudServerReconciler) Updating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	cloudServer := obj.(*v1alpha1.CloudServer)
 	return r.HandleUpdating(ctx, obj, status, func(ctx context.Context) error {
-		// Re-resolve all IDs in case references changed
 		projectID := cloudServer.Status.ProjectID
-		vpcID := cloudServer.Status.VpcID
-
-		// Check if we need to update cloud server properties (generation mismatch)
-		needsPropertyUpdate := status.ObservedGeneration != cloudServer.GetGeneration()
-
-		if needsPropertyUpdate {
-			// Resolve subnet IDs
-			subnetIDs := make([]string, len(cloudServer.Spec.SubnetReferences))
-			for i, subnetRef := range cloudServer.Spec.SubnetReferences {
-				subnetID, err := r.GetSubnetID(ctx, subnetRef.Name, subnetRef.Namespace)
-				if err != nil {
-					return fmt.Errorf("failed to get subnet ID for %s/%s: %w", subnetRef.Namespace, subnetRef.Name, err)
-				}
-				subnetIDs[i] = subnetID
-			}
 
-			// Resolve security group IDs
-			securityGroupIDs := make([]string, len(cloudServer.Spec.SecurityGroupReferences))
-			for i, sgRef := range cloudServer.Spec.SecurityGroupReferences {
-				sgID, err := r.GetSecurityGroupID(ctx, sgRef.Name, sgRef.Namespace)
-				if err != nil {
-					return fmt.Errorf("failed to get security group ID for %s/%s: %w", sgRef.Namespace, sgRef.Name, err)
-				}
-				securityGroupIDs[i] = sgID
-			}
-
-			// Update cloud server via API
-			cloudServerReq := arubaClient.CloudServerRequest{
-				Metadata: arubaClient.CloudServerMetadata{
-					Name: cloudServer.Name,
-					Tags: cloudServer.Spec.Tags,
-					Location: arubaClient.CloudServerLocation{
-						Value: cloudServer.Spec.Location.Value,
-					},
-				},
-			}
-
-			// Add optional fields
-			var elasticIpID string
-			if cloudServer.Spec.ElasticIpReference != nil {
-				elasticIpID, err := r.GetElasticIpID(ctx, cloudServer.Spec.ElasticIpReference.Name, cloudServer.Spec.ElasticIpReference.Namespace)
-				if err != nil {
-					return fmt.Errorf("failed to get elastic IP ID: %w", err)
-				}
-				cloudServerReq.Properties.ElasticIp = &arubaClient.CloudServerResourceReference{URI: r.buildElasticIpURI(projectID, elasticIpID)}
-			}
-
-			// Add subnets and security groups
-			for _, subnetID := range subnetIDs {
-				cloudServerReq.Properties.Subnets = append(cloudServerReq.Properties.Subnets,
-					arubaClient.CloudServerResourceReference{URI: r.buildSubnetURI(projectID, vpcID, subnetID)})
-			}
-			for _, sgID := range securityGroupIDs {
-				cloudServerReq.Properties.SecurityGroups = append(cloudServerReq.Properties.SecurityGroups,
-					arubaClient.CloudServerResourceReference{URI: r.buildSecurityGroupURI(projectID, vpcID, sgID)})
-			}
+		// The update was decided when moving to Updating, the spec is always sent
+		if err := r.updateCloudServerProperties(ctx, cloudServer, status); err != nil {
+			return err
+		}
 
-			_, err := r.UpdateCloudServer(ctx, cloudServer.Status.ProjectID, status.ResourceID, cloudServerReq)
+		// Volumes attached or detached out-of-band are corrected against the remote server
+		if meta.IsStatusConditionTrue(status.Conditions, v1alpha1.ConditionTypeDrifted) {
+			cloudServerResp, err := r.GetCloudServer(ctx, projectID, status.ResourceID)
 			if err != nil {
 				return err
 			}
-
-			// Update status with new resolved IDs
-			cloudServer.Status.SubnetIDs = subnetIDs
-			cloudServer.Status.SecurityGroupIDs = securityGroupIDs
-			if elasticIpID != "" {
-				cloudServer.Status.ElasticIpID = elasticIpID
-			} else {
-				cloudServer.Status.ElasticIpID = ""
-			}
+			cloudServer.Status.DataVolumeIDs = resourceIDs(cloudServerResp.Properties.DataVolumes)
 		}
 
 		// Now handle data volume management
@@ -278,6 +320,79 @@ func (r *CloudServerReconciler) Updating(ctx context.Context, obj client.Object,
 	})
 }
 
+// updateCloudServerProperties sends the spec of the cloud server to the API
+func (r *CloudServerReconciler) updateCloudServerProperties(ctx context.Context, cloudServer *v1alpha1.CloudServer, status *v1alpha1.ResourceStatus) error {
+	// Re-resolve all IDs in case references changed
+	projectID := cloudServer.Status.ProjectID
+	vpcID := cloudServer.Status.VpcID
+
+	// Resolve subnet IDs
+	subnetIDs := make([]string, len(cloudServer.Spec.SubnetReferences))
+	for i, subnetRef := range cloudServer.Spec.SubnetReferences {
+		subnetID, err := r.GetSubnetID(ctx, subnetRef.Name, subnetRef.Namespace)
+		if err != nil {
+			return fmt.Errorf("failed to get subnet ID for %s/%s: %w", subnetRef.Namespace, subnetRef.Name, err)
+		}
+		subnetIDs[i] = subnetID
+	}
+
+	// Resolve security group IDs
+	securityGroupIDs := make([]string, len(cloudServer.Spec.SecurityGroupReferences))
+	for i, sgRef := range cloudServer.Spec.SecurityGroupReferences {
+		sgID, err := r.GetSecurityGroupID(ctx, sgRef.Name, sgRef.Namespace)
+		if err != nil {
+			return fmt.Errorf("failed to get security group ID for %s/%s: %w", sgRef.Namespace, sgRef.Name, err)
+		}
+		securityGroupIDs[i] = sgID
+	}
+
+	// Update cloud server via API
+	cloudServerReq := arubaClient.CloudServerRequest{
+		Metadata: arubaClient.CloudServerMetadata{
+			Name: cloudServer.Name,
+			Tags: reconciler.OwnedTags(cloudServer, cloudServer.Spec.Tags),
+			Location: arubaClient.CloudServerLocation{
+				Value: cloudServer.Spec.Location.Value,
+			},
+		},
+		Properties: arubaClient.CloudServerProperties{
+			FlavorName: cloudServer.Spec.FlavorName,
+		},
+	}
+
+	// Add optional fields
+	var elasticIpID string
+	if cloudServer.Spec.ElasticIpReference != nil {
+		id, err := r.GetElasticIpID(ctx, cloudServer.Spec.ElasticIpReference.Name, cloudServer.Spec.ElasticIpReference.Namespace)
+		if err != nil {
+			return fmt.Errorf("failed to get elastic IP ID: %w", err)
+		}
+		elasticIpID = id
+		cloudServerReq.Properties.ElasticIp = &arubaClient.CloudServerResourceReference{URI: r.buildElasticIpURI(projectID, elasticIpID)}
+	}
+
+	// Add subnets and security groups
+	for _, subnetID := range subnetIDs {
+		cloudServerReq.Properties.Subnets = append(cloudServerReq.Properties.Subnets,
+			arubaClient.CloudServerResourceReference{URI: r.buildSubnetURI(projectID, vpcID, subnetID)})
+	}
+	for _, sgID := range securityGroupIDs {
+		cloudServerReq.Properties.SecurityGroups = append(cloudServerReq.Properties.SecurityGroups,
+			arubaClient.CloudServerResourceReference{URI: r.buildSecurityGroupURI(projectID, vpcID, sgID)})
+	}
+
+	_, err := r.UpdateCloudServer(ctx, projectID, status.ResourceID, cloudServerReq)
+	if err != nil {
+		return err
+	}
+
+	// Update status with new resolved IDs
+	cloudServer.Status.SubnetIDs = subnetIDs
+	cloudServer.Status.SecurityGroupIDs = securityGroupIDs
+	cloudServer.Status.ElasticIpID = elasticIpID
+	return nil
+}
+
 // manageDataVolumesInUpdate handles attaching and detaching data volumes during update phase
 func (r *CloudServerReconciler) manageDataVolumesInUpdate(ctx context.Context, cloudServer *v1alpha1.CloudServer, projectID string) error {
 	phaseLogger := ctrl.Log.WithValues("Phase", "Updating", "Kind", cloudServer.GetObjectKind().GroupVersionKind().Kind, "Name", cloudServer.GetName())
@@ -355,8 +470,46 @@ func (r *CloudServerReconciler) Created(ctx context.Context, obj client.Object,
 		)
 	}
 
-	// Check for other updates (generation mismatch)
-	return r.CheckForUpdates(ctx, obj, status)
+	// Check for other updates (generation mismatch) and drift of the remote server
+	return r.HandleCreated(ctx, obj, status, func(ctx context.Context) ([]string, error) {
+		cloudServerResp, err := r.GetCloudServer(ctx, cloudServer.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		var driftedFields []string
+		if !util.SameElements(cloudServer.Spec.Tags, reconciler.WithoutOwnershipTag(cloudServerResp.Metadata.Tags)) {
+			driftedFields = append(driftedFields, "spec.tags")
+		}
+		if cloudServer.Spec.FlavorName != cloudServerResp.Properties.FlavorName {
+			driftedFields = append(driftedFields, "spec.flavorName")
+		}
+
+		if !util.SameElements(cloudServer.Status.SecurityGroupIDs, resourceIDs(cloudServerResp.Properties.SecurityGroups)) {
+			driftedFields = append(driftedFields, "spec.securityGroupReferences")
+		}
+		if !util.SameElements(cloudServer.Status.DataVolumeIDs, resourceIDs(cloudServerResp.Properties.DataVolumes)) {
+			driftedFields = append(driftedFields, "spec.dataVolumeReferences")
+		}
+
+		remoteElasticIpID := ""
+		if cloudServerResp.Properties.ElasticIp != nil {
+			remoteElasticIpID = util.IDFromURI(cloudServerResp.Properties.ElasticIp.URI)
+		}
+		if cloudServer.Status.ElasticIpID != remoteElasticIpID {
+			driftedFields = append(driftedFields, "spec.elasticIpReference")
+		}
+		return driftedFields, nil
+	})
+}
+
+// resourceIDs returns the IDs of the referenced resources
+func resourceIDs(refs []arubaClient.CloudServerResourceReference) []string {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, util.IDFromURI(ref.URI))
+	}
+	return ids
 }
 
 // checkDataVolumesNeedUpdate checks if data volumes need to be attached or detached
@@ -382,6 +535,9 @@ func (r *CloudServerReconciler) Deleting(ctx context.Context, obj client.Object,
 	cloudServer := obj.(*v1alpha1.CloudServer)
 	return r.HandleDeletion(ctx, obj, status, cloudServerFinalizerName, func(ctx context.Context) error {
 		return r.DeleteCloudServer(ctx, cloudServer.Status.ProjectID, status.ResourceID)
+	}, func(ctx context.Context) error {
+		_, err := r.GetCloudServer(ctx, cloudServer.Status.ProjectID, status.ResourceID)
+		return err
 	})
 }
 
@@ -441,6 +441,94 @@ var _ = Describe("CloudServer Controller Reconcile Method", func() {
 			}
 		})
 
+		It("should send the edited spec when updating", func() {
+			By("Creating resource whose spec was edited after creation")
+			testName := fmt.Sprintf("test-update-put-cs-%d", GinkgoRandomSeed())
+			arubaCloudServer = &v1alpha1.CloudServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: "default",
+				},
+				Spec: v1alpha1.CloudServerSpec{
+					Tenant: "test-tenant",
+					Tags:   []string{"edited"},
+					Location: v1alpha1.Location{
+						Value: "ITBG-Bergamo",
+					},
+					DataCenter: "ITBG-1",
+					VpcReference: v1alpha1.ResourceReference{
+						Name:      "test-vpc",
+						Namespace: "default",
+					},
+					FlavorName: "CSO8A16",
+					SubnetReferences: []v1alpha1.ResourceReference{
+						{Name: "test-subnet", Namespace: "default"},
+					},
+					KeyPairReference: v1alpha1.ResourceReference{
+						Name:      "test-keypair",
+						Namespace: "default",
+					},
+					BootVolumeReference: v1alpha1.ResourceReference{
+						Name:      "test-boot-volume",
+						Namespace: "default",
+					},
+					ProjectReference: v1alpha1.ResourceReference{
+						Name:      "test-project",
+						Namespace: "default",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, arubaCloudServer)).To(Succeed())
+
+			// Next has already recorded the generation when moving to Updating
+			now := metav1.Now()
+			arubaCloudServer.Status.ResourceStatus = v1alpha1.ResourceStatus{
+				Phase:              v1alpha1.ResourcePhaseUpdating,
+				PhaseStartTime:     &now,
+				ResourceID:         "cs-123",
+				ObservedGeneration: arubaCloudServer.Generation,
+			}
+			arubaCloudServer.Status.ProjectID = "project-123"
+			arubaCloudServer.Status.VpcID = "vpc-123"
+			Expect(k8sClient.Status().Update(ctx, arubaCloudServer)).To(Succeed())
+
+			var putBody string
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			mockHTTPClient.On("Do", mock.AnythingOfType("*http.Request")).Return(
+				func(req *http.Request) (*http.Response, error) {
+					if req.Method == http.MethodPut {
+						body, _ := io.ReadAll(req.Body)
+						putBody = string(body)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`{}`)),
+						Header:     make(http.Header),
+					}, nil
+				}, nil)
+			cloudServerReconciler.HelperClient = client.NewHelperClient(k8sClient, mockHTTPClient, "https://api.example.com")
+
+			By("Running the Updating phase")
+			// The references are left out so that no dependency has to be resolved
+			arubaCloudServer.Spec.SubnetReferences = nil
+			_, err := cloudServerReconciler.Updating(client.WithAPIToken(ctx, "token"), arubaCloudServer, &arubaCloudServer.Status.ResourceStatus)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the spec was sent to the API")
+			mockHTTPClient.AssertCalled(GinkgoT(), "Do", mock.MatchedBy(func(req *http.Request) bool {
+				return req.Method == http.MethodPut && strings.HasSuffix(req.URL.Path, "/cloudServers/cs-123")
+			}))
+			Expect(putBody).To(ContainSubstring(`"flavorName":"CSO8A16"`))
+			Expect(putBody).To(ContainSubstring(`"edited"`))
+
+			updatedCloudServer := &v1alpha1.CloudServer{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: testName, Namespace: "default"}, updatedCloudServer)).To(Succeed())
+			Expect(updatedCloudServer.Status.Phase).To(Equal(v1alpha1.ResourcePhaseCreated))
+
+			By("Cleanup")
+			Expect(k8sClient.Delete(ctx, updatedCloudServer)).To(Succeed())
+		})
+
 		It("should test Next method", func() {
 			By("Creating resource")
 			testName := fmt.Sprintf("test-next-method-cs-%d", GinkgoRandomSeed())
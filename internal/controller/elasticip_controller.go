@@ -9,6 +9,7 @@ import (
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
 )
 
 // ElasticIpReconciler reconciles a ElasticIp object
@@ -35,9 +36,19 @@ func (r *ElasticIpReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ElasticIpReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.ElasticIp{}, reconciler.ProjectReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.ElasticIp).Spec.ProjectReference}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.ElasticIp{}).
+		Watches(&v1alpha1.Project{}, r.EnqueueReferencing(&v1alpha1.ElasticIpList{}, reconciler.ProjectReferenceIndex), reconciler.ReferencedStatusChanged()).
 		Named("elasticip").
+		WithOptions(r.ControllerOptions()).
 		Complete(r)
 }
 
@@ -49,6 +60,14 @@ func (r *ElasticIpReconciler) Init(ctx context.Context, obj client.Object, statu
 	return r.InitializeResource(ctx, obj, status, elasticIpFinalizerName)
 }
 
+// Dependencies returns the resources that must be provisioned before the ElasticIp can be created or updated
+func (r *ElasticIpReconciler) Dependencies(obj client.Object) []reconciler.Dependency {
+	elasticIp := obj.(*v1alpha1.ElasticIp)
+	return []reconciler.Dependency{
+		{Kind: "Project", Reference: elasticIp.Spec.ProjectReference},
+	}
+}
+
 func (r *ElasticIpReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	elasticIp := obj.(*v1alpha1.ElasticIp)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
@@ -64,7 +83,7 @@ func (r *ElasticIpReconciler) Creating(ctx context.Context, obj client.Object, s
 		elasticIpReq := arubaClient.ElasticIpRequest{
 			Metadata: arubaClient.ElasticIpMetadata{
 				Name: elasticIp.Name,
-				Tags: elasticIp.Spec.Tags,
+				Tags: reconciler.OwnedTags(elasticIp, elasticIp.Spec.Tags),
 				Location: arubaClient.ElasticIpLocation{
 					Value: elasticIp.Spec.Location.Value,
 				},
@@ -76,10 +95,20 @@ func (r *ElasticIpReconciler) Creating(ctx context.Context, obj client.Object, s
 			},
 		}
 
-		elasticIpResp, err := r.CreateElasticIp(ctx, projectID, elasticIpReq)
+		// Adopt a ElasticIp created by a previous attempt whose ID was never recorded
+		elasticIpList, err := r.ListElasticIps(ctx, projectID)
 		if err != nil {
 			return "", "", err
 		}
+		elasticIpResp := reconciler.FindOwned(obj, elasticIpList.Values, func(remote *arubaClient.ElasticIpResponse) []string {
+			return remote.Metadata.Tags
+		})
+		if elasticIpResp == nil {
+			elasticIpResp, err = r.CreateElasticIp(ctx, projectID, elasticIpReq)
+			if err != nil {
+				return "", "", err
+			}
+		}
 
 		elasticIp.Status.ProjectID = projectID
 
@@ -92,6 +121,23 @@ func (r *ElasticIpReconciler) Creating(ctx context.Context, obj client.Object, s
 	})
 }
 
+// Import adopts an existing remote ElasticIp and records the IDs of its parent resources
+func (r *ElasticIpReconciler) Import(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, resourceID string) error {
+	elasticIp := obj.(*v1alpha1.ElasticIp)
+	projectID, err := r.GetProjectID(ctx, elasticIp.Spec.ProjectReference.Name, elasticIp.Spec.ProjectReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.GetElasticIp(ctx, projectID, resourceID)
+	if err != nil {
+		return err
+	}
+
+	elasticIp.Status.ProjectID = projectID
+	return nil
+}
+
 func (r *ElasticIpReconciler) Provisioning(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	elasticIp := obj.(*v1alpha1.ElasticIp)
 	return r.HandleProvisioning(ctx, obj, status, func(ctx context.Context) (string, error) {
@@ -113,7 +159,7 @@ func (r *ElasticIpReconciler) Updating(ctx context.Context, obj client.Object, s
 		elasticIpReq := arubaClient.ElasticIpRequest{
 			Metadata: arubaClient.ElasticIpMetadata{
 				Name: elasticIp.Name,
-				Tags: elasticIp.Spec.Tags,
+				Tags: reconciler.OwnedTags(elasticIp, elasticIp.Spec.Tags),
 				Location: arubaClient.ElasticIpLocation{
 					Value: elasticIp.Spec.Location.Value,
 				},
@@ -131,12 +177,30 @@ func (r *ElasticIpReconciler) Updating(ctx context.Context, obj client.Object, s
 }
 
 func (r *ElasticIpReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
-	return r.CheckForUpdates(ctx, obj, status)
+	elasticIp := obj.(*v1alpha1.ElasticIp)
+	return r.HandleCreated(ctx, obj, status, func(ctx context.Context) ([]string, error) {
+		elasticIpResp, err := r.GetElasticIp(ctx, elasticIp.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		var driftedFields []string
+		if !util.SameElements(elasticIp.Spec.Tags, reconciler.WithoutOwnershipTag(elasticIpResp.Metadata.Tags)) {
+			driftedFields = append(driftedFields, "spec.tags")
+		}
+		if elasticIp.Spec.BillingPlan.BillingPeriod != elasticIpResp.Properties.BillingPlan.BillingPeriod {
+			driftedFields = append(driftedFields, "spec.billingPlan.billingPeriod")
+		}
+		return driftedFields, nil
+	})
 }
 
 func (r *ElasticIpReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	elasticIp := obj.(*v1alpha1.ElasticIp)
 	return r.HandleDeletion(ctx, obj, status, elasticIpFinalizerName, func(ctx context.Context) error {
 		return r.DeleteElasticIp(ctx, elasticIp.Status.ProjectID, status.ResourceID)
+	}, func(ctx context.Context) error {
+		_, err := r.GetElasticIp(ctx, elasticIp.Status.ProjectID, status.ResourceID)
+		return err
 	})
 }
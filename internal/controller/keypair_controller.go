@@ -25,6 +25,7 @@ import (
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
 )
 
 // KeyPairReconciler reconciles a KeyPair object
@@ -53,9 +54,19 @@ func (r *KeyPairReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *KeyPairReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.KeyPair{}, reconciler.ProjectReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.KeyPair).Spec.ProjectReference}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.KeyPair{}).
+		Watches(&v1alpha1.Project{}, r.EnqueueReferencing(&v1alpha1.KeyPairList{}, reconciler.ProjectReferenceIndex), reconciler.ReferencedStatusChanged()).
 		Named("keypair").
+		WithOptions(r.ControllerOptions()).
 		Complete(r)
 }
 
@@ -67,6 +78,14 @@ func (r *KeyPairReconciler) Init(ctx context.Context, obj client.Object, status
 	return r.InitializeResource(ctx, obj, status, keyPairFinalizerName)
 }
 
+// Dependencies returns the resources that must be provisioned before the KeyPair can be created or updated
+func (r *KeyPairReconciler) Dependencies(obj client.Object) []reconciler.Dependency {
+	keyPair := obj.(*v1alpha1.KeyPair)
+	return []reconciler.Dependency{
+		{Kind: "Project", Reference: keyPair.Spec.ProjectReference},
+	}
+}
+
 func (r *KeyPairReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	keyPair := obj.(*v1alpha1.KeyPair)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
@@ -82,7 +101,7 @@ func (r *KeyPairReconciler) Creating(ctx context.Context, obj client.Object, sta
 		keyPairReq := arubaClient.KeyPairRequest{
 			Metadata: arubaClient.KeyPairMetadata{
 				Name: keyPair.Name,
-				Tags: keyPair.Spec.Tags,
+				Tags: reconciler.OwnedTags(keyPair, keyPair.Spec.Tags),
 				Location: arubaClient.KeyPairLocation{
 					Value: keyPair.Spec.Location.Value,
 				},
@@ -92,10 +111,20 @@ func (r *KeyPairReconciler) Creating(ctx context.Context, obj client.Object, sta
 			},
 		}
 
-		keyPairResp, err := r.CreateKeyPair(ctx, projectID, keyPairReq)
+		// Adopt a KeyPair created by a previous attempt whose ID was never recorded
+		keyPairList, err := r.ListKeyPairs(ctx, projectID)
 		if err != nil {
 			return "", "", err
 		}
+		keyPairResp := reconciler.FindOwned(obj, keyPairList.Values, func(remote *arubaClient.KeyPairResponse) []string {
+			return remote.Metadata.Tags
+		})
+		if keyPairResp == nil {
+			keyPairResp, err = r.CreateKeyPair(ctx, projectID, keyPairReq)
+			if err != nil {
+				return "", "", err
+			}
+		}
 
 		keyPair.Status.ProjectID = projectID
 
@@ -108,6 +137,23 @@ func (r *KeyPairReconciler) Creating(ctx context.Context, obj client.Object, sta
 	})
 }
 
+// Import adopts an existing remote KeyPair and records the IDs of its parent resources
+func (r *KeyPairReconciler) Import(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, resourceID string) error {
+	keyPair := obj.(*v1alpha1.KeyPair)
+	projectID, err := r.GetProjectID(ctx, keyPair.Spec.ProjectReference.Name, keyPair.Spec.ProjectReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.GetKeyPair(ctx, projectID, resourceID)
+	if err != nil {
+		return err
+	}
+
+	keyPair.Status.ProjectID = projectID
+	return nil
+}
+
 func (r *KeyPairReconciler) Provisioning(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	keyPair := obj.(*v1alpha1.KeyPair)
 	return r.HandleProvisioning(ctx, obj, status, func(ctx context.Context) (string, error) {
@@ -129,7 +175,7 @@ func (r *KeyPairReconciler) Updating(ctx context.Context, obj client.Object, sta
 		keyPairReq := arubaClient.KeyPairUpdateRequest{
 			Metadata: arubaClient.KeyPairMetadata{
 				Name: keyPair.Name,
-				Tags: keyPair.Spec.Tags,
+				Tags: reconciler.OwnedTags(keyPair, keyPair.Spec.Tags),
 				Location: arubaClient.KeyPairLocation{
 					Value: keyPair.Spec.Location.Value,
 				},
@@ -142,12 +188,27 @@ func (r *KeyPairReconciler) Updating(ctx context.Context, obj client.Object, sta
 }
 
 func (r *KeyPairReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
-	return r.CheckForUpdates(ctx, obj, status)
+	keyPair := obj.(*v1alpha1.KeyPair)
+	return r.HandleCreated(ctx, obj, status, func(ctx context.Context) ([]string, error) {
+		keyPairResp, err := r.GetKeyPair(ctx, keyPair.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		var driftedFields []string
+		if !util.SameElements(keyPair.Spec.Tags, reconciler.WithoutOwnershipTag(keyPairResp.Metadata.Tags)) {
+			driftedFields = append(driftedFields, "spec.tags")
+		}
+		return driftedFields, nil
+	})
 }
 
 func (r *KeyPairReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	keyPair := obj.(*v1alpha1.KeyPair)
 	return r.HandleDeletion(ctx, obj, status, keyPairFinalizerName, func(ctx context.Context) error {
 		return r.DeleteKeyPair(ctx, keyPair.Status.ProjectID, status.ResourceID)
+	}, func(ctx context.Context) error {
+		_, err := r.GetKeyPair(ctx, keyPair.Status.ProjectID, status.ResourceID)
+		return err
 	})
 }
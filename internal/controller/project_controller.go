@@ -19,12 +19,15 @@ package controller
 import (
 	"context"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
 )
 
 // ProjectReconciler reconciles a Project object
@@ -55,6 +58,7 @@ func (r *ProjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.Project{}).
 		Named("project").
+		WithOptions(r.ControllerOptions()).
 		Complete(r)
 }
 
@@ -72,7 +76,7 @@ func (r *ProjectReconciler) Creating(ctx context.Context, obj client.Object, sta
 		projectReq := arubaClient.ProjectRequest{
 			Metadata: arubaClient.ProjectMetadata{
 				Name: project.Name,
-				Tags: project.Spec.Tags,
+				Tags: reconciler.OwnedTags(project, project.Spec.Tags),
 			},
 			Properties: arubaClient.ProjectProperties{
 				Description: project.Spec.Description,
@@ -80,17 +84,43 @@ func (r *ProjectReconciler) Creating(ctx context.Context, obj client.Object, sta
 			},
 		}
 
-		projectResp, err := r.CreateProject(ctx, projectReq)
+		// Adopt a Project created by a previous attempt whose ID was never recorded
+		projectList, err := r.ListProjects(ctx)
 		if err != nil {
 			return "", "", err
 		}
+		projectResp := reconciler.FindOwned(obj, projectList.Values, func(remote *arubaClient.ProjectResponse) []string {
+			return remote.Metadata.Tags
+		})
+		if projectResp == nil {
+			projectResp, err = r.CreateProject(ctx, projectReq)
+			if err != nil {
+				return "", "", err
+			}
+		}
 
 		return projectResp.Metadata.ID, "", nil
 	})
 }
 
+// Import adopts an existing remote Project
+func (r *ProjectReconciler) Import(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, resourceID string) error {
+	_, err := r.GetProject(ctx, resourceID)
+	return err
+}
+
 func (r *ProjectReconciler) Provisioning(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
-	return ctrl.Result{}, nil
+	// Projects are available as soon as they are created, there is no remote state to wait for
+	return r.Next(
+		ctx,
+		obj,
+		status,
+		v1alpha1.ResourcePhaseCreated,
+		metav1.ConditionTrue,
+		"Created",
+		"Resource created successfully",
+		true,
+	)
 }
 
 func (r *ProjectReconciler) Updating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
@@ -99,7 +129,7 @@ func (r *ProjectReconciler) Updating(ctx context.Context, obj client.Object, sta
 		projectReq := arubaClient.ProjectRequest{
 			Metadata: arubaClient.ProjectMetadata{
 				Name: project.Name,
-				Tags: project.Spec.Tags,
+				Tags: reconciler.OwnedTags(project, project.Spec.Tags),
 			},
 			Properties: arubaClient.ProjectProperties{
 				Description: project.Spec.Description,
@@ -113,11 +143,32 @@ func (r *ProjectReconciler) Updating(ctx context.Context, obj client.Object, sta
 }
 
 func (r *ProjectReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
-	return r.CheckForUpdates(ctx, obj, status)
+	project := obj.(*v1alpha1.Project)
+	return r.HandleCreated(ctx, obj, status, func(ctx context.Context) ([]string, error) {
+		projectResp, err := r.GetProject(ctx, status.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		var driftedFields []string
+		if !util.SameElements(project.Spec.Tags, reconciler.WithoutOwnershipTag(projectResp.Metadata.Tags)) {
+			driftedFields = append(driftedFields, "spec.tags")
+		}
+		if project.Spec.Description != projectResp.Properties.Description {
+			driftedFields = append(driftedFields, "spec.description")
+		}
+		if project.Spec.Default != projectResp.Properties.Default {
+			driftedFields = append(driftedFields, "spec.default")
+		}
+		return driftedFields, nil
+	})
 }
 
 func (r *ProjectReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	return r.HandleDeletion(ctx, obj, status, projectFinalizerName, func(ctx context.Context) error {
 		return r.DeleteProject(ctx, status.ResourceID)
+	}, func(ctx context.Context) error {
+		_, err := r.GetProject(ctx, status.ResourceID)
+		return err
 	})
 }
@@ -337,6 +337,229 @@ var _ = Describe("Project Controller Reconcile Method", func() {
 			}
 		})
 
+		It("should retry a failed resource when the retry annotation is set", func() {
+			By("Creating resource")
+			testName := fmt.Sprintf("test-retry-failed-%d", GinkgoRandomSeed())
+			namespacedName := types.NamespacedName{
+				Name:      testName,
+				Namespace: "default",
+			}
+			arubaProject = &v1alpha1.Project{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: "default",
+					Annotations: map[string]string{
+						v1alpha1.AnnotationRetry: "true",
+					},
+				},
+				Spec: v1alpha1.ProjectSpec{
+					Tenant:      "test-tenant",
+					Description: "Test project for failed retry",
+					Tags:        []string{"test", "retry"},
+					Default:     false,
+				},
+			}
+			Expect(k8sClient.Create(ctx, arubaProject)).To(Succeed())
+
+			By("Moving the resource to the Failed phase")
+			now := metav1.Now()
+			arubaProject.Status = v1alpha1.ResourceStatus{
+				Phase:              v1alpha1.ResourcePhaseFailed,
+				PhaseStartTime:     &now,
+				ResourceID:         "project-123",
+				ObservedGeneration: arubaProject.Generation,
+			}
+			Expect(k8sClient.Status().Update(ctx, arubaProject)).To(Succeed())
+
+			By("Reconciling the resource")
+			_, err := resourceReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: namespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the resource left the Failed phase")
+			updatedProject := &v1alpha1.Project{}
+			Expect(k8sClient.Get(ctx, namespacedName, updatedProject)).To(Succeed())
+			Expect(updatedProject.Status.Phase).To(Equal(v1alpha1.ResourcePhaseProvisioning))
+			Expect(updatedProject.Status.RetryCount).To(Equal(int32(1)))
+			Expect(updatedProject.Annotations).NotTo(HaveKey(v1alpha1.AnnotationRetry))
+
+			By("Cleanup")
+			Expect(k8sClient.Delete(ctx, updatedProject)).To(Succeed())
+		})
+
+		It("should import an existing project instead of creating it", func() {
+			By("Creating resource with the import annotation")
+			testName := fmt.Sprintf("test-import-%d", GinkgoRandomSeed())
+			namespacedName := types.NamespacedName{
+				Name:      testName,
+				Namespace: "default",
+			}
+			arubaProject = &v1alpha1.Project{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: "default",
+					Annotations: map[string]string{
+						v1alpha1.AnnotationImportID: "project-existing",
+					},
+				},
+				Spec: v1alpha1.ProjectSpec{
+					Tenant:      "test-tenant",
+					Description: "Test project for import",
+					Tags:        []string{"test", "import"},
+					Default:     false,
+				},
+			}
+			Expect(k8sClient.Create(ctx, arubaProject)).To(Succeed())
+
+			now := metav1.Now()
+			arubaProject.Status = v1alpha1.ResourceStatus{
+				Phase:          v1alpha1.ResourcePhaseCreating,
+				PhaseStartTime: &now,
+			}
+			Expect(k8sClient.Status().Update(ctx, arubaProject)).To(Succeed())
+
+			By("Reconciling the resource")
+			_, err := resourceReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: namespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the remote project was adopted")
+			updatedProject := &v1alpha1.Project{}
+			Expect(k8sClient.Get(ctx, namespacedName, updatedProject)).To(Succeed())
+			Expect(updatedProject.Status.Phase).To(Equal(v1alpha1.ResourcePhaseCreated))
+			Expect(updatedProject.Status.ResourceID).To(Equal("project-existing"))
+
+			By("Cleanup")
+			Expect(k8sClient.Delete(ctx, updatedProject)).To(Succeed())
+		})
+
+		It("should adopt a project created by a previous attempt instead of creating a duplicate", func() {
+			By("Creating resource in the Creating phase")
+			testName := fmt.Sprintf("test-adopt-%d", GinkgoRandomSeed())
+			namespacedName := types.NamespacedName{
+				Name:      testName,
+				Namespace: "default",
+			}
+			arubaProject = &v1alpha1.Project{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ProjectSpec{
+					Tenant:      "test-tenant",
+					Description: "Test project for adoption",
+					Tags:        []string{"test", "adopt"},
+					Default:     false,
+				},
+			}
+			Expect(k8sClient.Create(ctx, arubaProject)).To(Succeed())
+
+			now := metav1.Now()
+			arubaProject.Status = v1alpha1.ResourceStatus{
+				Phase:          v1alpha1.ResourcePhaseCreating,
+				PhaseStartTime: &now,
+			}
+			Expect(k8sClient.Status().Update(ctx, arubaProject)).To(Succeed())
+
+			// The project list already holds a project tagged with the UID of this object
+			listBody := fmt.Sprintf(`{"total": 1, "values": [{"metadata": {"id": "project-owned", "name": %q, "tags": ["test", "adopt", %q]}}]}`,
+				testName, reconciler.OwnershipTag(arubaProject))
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			mockHTTPClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+				return req.Method == http.MethodGet
+			})).Return(
+				func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(listBody)),
+						Header:     make(http.Header),
+					}, nil
+				}, nil)
+			resourceReconciler.HelperClient = client.NewHelperClient(k8sClient, mockHTTPClient, "https://api.example.com")
+
+			By("Reconciling the resource")
+			_, err := resourceReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: namespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the existing project was adopted without a POST")
+			updatedProject := &v1alpha1.Project{}
+			Expect(k8sClient.Get(ctx, namespacedName, updatedProject)).To(Succeed())
+			Expect(updatedProject.Status.ResourceID).To(Equal("project-owned"))
+			mockHTTPClient.AssertNotCalled(GinkgoT(), "Do", mock.MatchedBy(func(req *http.Request) bool {
+				return req.Method == http.MethodPost
+			}))
+
+			By("Cleanup")
+			Expect(k8sClient.Delete(ctx, updatedProject)).To(Succeed())
+		})
+
+		It("should record the field errors of a rejected update in the status", func() {
+			By("Creating resource in the Updating phase")
+			testName := fmt.Sprintf("test-last-error-%d", GinkgoRandomSeed())
+			namespacedName := types.NamespacedName{
+				Name:      testName,
+				Namespace: "default",
+			}
+			arubaProject = &v1alpha1.Project{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ProjectSpec{
+					Tenant:      "test-tenant",
+					Description: "Test project for API errors",
+					Tags:        []string{"test", "errors"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, arubaProject)).To(Succeed())
+
+			now := metav1.Now()
+			arubaProject.Status = v1alpha1.ResourceStatus{
+				Phase:          v1alpha1.ResourcePhaseUpdating,
+				PhaseStartTime: &now,
+				ResourceID:     "project-123",
+			}
+			Expect(k8sClient.Status().Update(ctx, arubaProject)).To(Succeed())
+
+			errorBody := `{"type": "validation", "title": "Invalid request", "traceId": "trace-123",
+				"errors": [{"field": "Properties.Description", "message": "too long"}, {"field": "metadata.tags[1]", "message": "invalid tag"}]}`
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			mockHTTPClient.On("Do", mock.AnythingOfType("*http.Request")).Return(
+				func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusUnprocessableEntity,
+						Body:       io.NopCloser(strings.NewReader(errorBody)),
+						Header:     make(http.Header),
+					}, nil
+				}, nil)
+			resourceReconciler.HelperClient = client.NewHelperClient(k8sClient, mockHTTPClient, "https://api.example.com")
+
+			By("Reconciling the resource")
+			_, err := resourceReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: namespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the error is mapped to the spec fields")
+			updatedProject := &v1alpha1.Project{}
+			Expect(k8sClient.Get(ctx, namespacedName, updatedProject)).To(Succeed())
+			Expect(updatedProject.Status.Phase).To(Equal(v1alpha1.ResourcePhaseFailed))
+			Expect(updatedProject.Status.LastError).NotTo(BeNil())
+			Expect(updatedProject.Status.LastError.Status).To(Equal(int32(http.StatusUnprocessableEntity)))
+			Expect(updatedProject.Status.LastError.TraceID).To(Equal("trace-123"))
+			Expect(updatedProject.Status.LastError.FieldErrors).To(ConsistOf(
+				v1alpha1.FieldError{Field: "spec.description", Message: "too long"},
+				v1alpha1.FieldError{Field: "spec.tags[1]", Message: "invalid tag"},
+			))
+
+			By("Cleanup")
+			Expect(k8sClient.Delete(ctx, updatedProject)).To(Succeed())
+		})
+
 		It("should test Next method", func() {
 			By("Creating resource")
 			testName := fmt.Sprintf("test-next-method-%d", GinkgoRandomSeed())
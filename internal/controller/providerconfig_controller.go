@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apiError "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
+)
+
+// ProviderConfigReconciler reconciles a ProviderConfig object: it checks the credentials Secret
+// and drops the cached token of the tenant whenever the Secret or the ProviderConfig changes
+type ProviderConfigReconciler struct {
+	*reconciler.Reconciler
+}
+
+// NewProviderConfigReconciler creates a new ProviderConfigReconciler
+func NewProviderConfigReconciler(reconciler *reconciler.Reconciler) *ProviderConfigReconciler {
+	return &ProviderConfigReconciler{
+		Reconciler: reconciler,
+	}
+}
+
+// +kubebuilder:rbac:groups=arubacloud.com,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=providerconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *ProviderConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	providerConfig := &v1alpha1.ProviderConfig{}
+	if err := r.Get(ctx, req.NamespacedName, providerConfig); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	logger := ctrl.Log.WithValues("ProviderConfig", providerConfig.Name, "Tenant", providerConfig.Spec.Tenant)
+
+	status := &providerConfig.Status
+	_, secret, err := r.ProviderConfigCredentials(ctx, providerConfig)
+
+	secretResourceVersion := ""
+	if secret != nil {
+		secretResourceVersion = secret.ResourceVersion
+	}
+	if status.ObservedGeneration != providerConfig.Generation || status.SecretResourceVersion != secretResourceVersion {
+		logger.Info("Credentials changed, invalidating the cached token")
+		r.TokenManager.Invalidate(providerConfig.Spec.Tenant)
+	}
+	status.ObservedGeneration = providerConfig.Generation
+	status.SecretResourceVersion = secretResourceVersion
+
+	switch {
+	case secret == nil && apiError.IsNotFound(err):
+		status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeCredentialsReady, metav1.ConditionFalse, "SecretNotFound", err.Error())
+	case err != nil && secret == nil:
+		// Transient failure reading the Secret, retried with backoff
+		return ctrl.Result{}, err
+	case err != nil:
+		status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeCredentialsReady, metav1.ConditionFalse, "InvalidSecret", err.Error())
+	default:
+		status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeCredentialsReady, metav1.ConditionTrue, "SecretFound", "Credentials secret is valid")
+	}
+
+	if err := r.Status().Update(ctx, providerConfig); err != nil {
+		logger.Error(err, "failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ProviderConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.ProviderConfig{}, reconciler.ProviderConfigSecretIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		ref := obj.(*v1alpha1.ProviderConfig).Spec.CredentialsSecretRef
+		return []v1alpha1.ResourceReference{{Name: ref.Name, Namespace: ref.Namespace}}
+	}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ProviderConfig{}, builder.WithPredicates(predicate.Funcs{
+			// The tenant falls back to Vault or the static credentials, its token must not outlive the ProviderConfig
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				if providerConfig, ok := e.Object.(*v1alpha1.ProviderConfig); ok {
+					r.TokenManager.Invalidate(providerConfig.Spec.Tenant)
+				}
+				return false
+			},
+		})).
+		// Only the metadata of the Secrets is cached, their data is read when needed
+		WatchesMetadata(&corev1.Secret{}, r.EnqueueReferencing(&v1alpha1.ProviderConfigList{}, reconciler.ProviderConfigSecretIndex)).
+		Named("providerconfig").
+		Complete(r)
+}
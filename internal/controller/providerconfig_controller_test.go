@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/mocks"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+)
+
+var _ = Describe("ProviderConfig Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-provider-config"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+		secretName := types.NamespacedName{Name: "test-provider-config-credentials", Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating the credentials secret and the ProviderConfig")
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName.Name, Namespace: secretName.Namespace},
+				StringData: map[string]string{"client-id": "client", "client-secret": "secret"},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &v1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+				Spec: v1alpha1.ProviderConfigSpec{
+					Tenant: "provider-config-tenant",
+					CredentialsSecretRef: v1alpha1.CredentialsSecretReference{
+						Name:      secretName.Name,
+						Namespace: secretName.Namespace,
+					},
+					AllowedNamespaces: []string{"default"},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, &v1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: resourceName}})).To(Succeed())
+			secret := &corev1.Secret{}
+			if err := k8sClient.Get(ctx, secretName, secret); err == nil {
+				Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+			}
+		})
+
+		It("should invalidate the cached token when the credentials secret changes", func() {
+			auth := new(mocks.MockITokenManager)
+			auth.On("Invalidate", "provider-config-tenant").Return()
+
+			providerConfigReconciler := NewProviderConfigReconciler(&reconciler.Reconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				TokenManager: auth,
+			})
+
+			By("Reconciling the created resource")
+			_, err := providerConfigReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			providerConfig := &v1alpha1.ProviderConfig{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, providerConfig)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(providerConfig.Status.Conditions, v1alpha1.ConditionTypeCredentialsReady)).To(BeTrue())
+			auth.AssertNumberOfCalls(GinkgoT(), "Invalidate", 1)
+
+			By("Reconciling again without changes")
+			_, err = providerConfigReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			auth.AssertNumberOfCalls(GinkgoT(), "Invalidate", 1)
+
+			By("Rotating the credentials")
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, secretName, secret)).To(Succeed())
+			secret.Data["client-secret"] = []byte("rotated")
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+
+			_, err = providerConfigReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			auth.AssertNumberOfCalls(GinkgoT(), "Invalidate", 2)
+		})
+
+		It("should report a missing credentials secret", func() {
+			auth := new(mocks.MockITokenManager)
+			auth.On("Invalidate", mock.Anything).Return()
+
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, secretName, secret)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+
+			providerConfigReconciler := NewProviderConfigReconciler(&reconciler.Reconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				TokenManager: auth,
+			})
+			_, err := providerConfigReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			providerConfig := &v1alpha1.ProviderConfig{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, providerConfig)).To(Succeed())
+			condition := meta.FindStatusCondition(providerConfig.Status.Conditions, v1alpha1.ConditionTypeCredentialsReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("SecretNotFound"))
+		})
+
+		It("should refuse tenants to namespaces not allowed by the ProviderConfig", func() {
+			auth := new(mocks.MockITokenManager)
+			baseReconciler := &reconciler.Reconciler{
+				Client:       k8sClient,
+				Scheme:       k8sClient.Scheme(),
+				TokenManager: auth,
+			}
+
+			_, err := baseReconciler.Authenticate(ctx, "other", "provider-config-tenant")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not allowed"))
+			auth.AssertNotCalled(GinkgoT(), "GetActiveToken", mock.Anything)
+		})
+	})
+})
@@ -25,6 +25,7 @@ import (
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
 )
 
 // SecurityGroupReconciler reconciles a SecurityGroup object
@@ -43,6 +44,7 @@ func NewSecurityGroupReconciler(reconciler *reconciler.Reconciler) *SecurityGrou
 // +kubebuilder:rbac:groups=arubacloud.com,resources=securitygroups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=arubacloud.com,resources=securitygroups/finalizers,verbs=update
 // +kubebuilder:rbac:groups=arubacloud.com,resources=projects,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=vpcs,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
@@ -53,9 +55,26 @@ func (r *SecurityGroupReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SecurityGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.SecurityGroup{}, reconciler.ProjectReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.SecurityGroup).Spec.ProjectReference}
+	}); err != nil {
+		return err
+	}
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.SecurityGroup{}, reconciler.VpcReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.SecurityGroup).Spec.VpcReference}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.SecurityGroup{}).
+		Watches(&v1alpha1.Project{}, r.EnqueueReferencing(&v1alpha1.SecurityGroupList{}, reconciler.ProjectReferenceIndex), reconciler.ReferencedStatusChanged()).
+		Watches(&v1alpha1.Vpc{}, r.EnqueueReferencing(&v1alpha1.SecurityGroupList{}, reconciler.VpcReferenceIndex), reconciler.ReferencedStatusChanged()).
 		Named("securitygroup").
+		WithOptions(r.ControllerOptions()).
 		Complete(r)
 }
 
@@ -67,6 +86,15 @@ func (r *SecurityGroupReconciler) Init(ctx context.Context, obj client.Object, s
 	return r.InitializeResource(ctx, obj, status, securityGroupFinalizerName)
 }
 
+// Dependencies returns the resources that must be provisioned before the SecurityGroup can be created or updated
+func (r *SecurityGroupReconciler) Dependencies(obj client.Object) []reconciler.Dependency {
+	securityGroup := obj.(*v1alpha1.SecurityGroup)
+	return []reconciler.Dependency{
+		{Kind: "Project", Reference: securityGroup.Spec.ProjectReference},
+		{Kind: "Vpc", Reference: securityGroup.Spec.VpcReference},
+	}
+}
+
 func (r *SecurityGroupReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	securityGroup := obj.(*v1alpha1.SecurityGroup)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
@@ -87,7 +115,7 @@ func (r *SecurityGroupReconciler) Creating(ctx context.Context, obj client.Objec
 		securityGroupReq := arubaClient.SecurityGroupRequest{
 			Metadata: arubaClient.SecurityGroupMetadata{
 				Name: securityGroup.Name,
-				Tags: securityGroup.Spec.Tags,
+				Tags: reconciler.OwnedTags(securityGroup, securityGroup.Spec.Tags),
 				Location: arubaClient.SecurityGroupLocation{
 					Value: securityGroup.Spec.Location.Value,
 				},
@@ -97,10 +125,20 @@ func (r *SecurityGroupReconciler) Creating(ctx context.Context, obj client.Objec
 			},
 		}
 
-		securityGroupResp, err := r.CreateSecurityGroup(ctx, projectID, vpcID, securityGroupReq)
+		// Adopt a SecurityGroup created by a previous attempt whose ID was never recorded
+		securityGroupList, err := r.ListSecurityGroups(ctx, projectID, vpcID)
 		if err != nil {
 			return "", "", err
 		}
+		securityGroupResp := reconciler.FindOwned(obj, securityGroupList.Values, func(remote *arubaClient.SecurityGroupResponse) []string {
+			return remote.Metadata.Tags
+		})
+		if securityGroupResp == nil {
+			securityGroupResp, err = r.CreateSecurityGroup(ctx, projectID, vpcID, securityGroupReq)
+			if err != nil {
+				return "", "", err
+			}
+		}
 
 		securityGroup.Status.ProjectID = projectID
 		securityGroup.Status.VpcID = vpcID
@@ -114,6 +152,29 @@ func (r *SecurityGroupReconciler) Creating(ctx context.Context, obj client.Objec
 	})
 }
 
+// Import adopts an existing remote SecurityGroup and records the IDs of its parent resources
+func (r *SecurityGroupReconciler) Import(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, resourceID string) error {
+	securityGroup := obj.(*v1alpha1.SecurityGroup)
+	projectID, err := r.GetProjectID(ctx, securityGroup.Spec.ProjectReference.Name, securityGroup.Spec.ProjectReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	vpcID, err := r.GetVpcID(ctx, securityGroup.Spec.VpcReference.Name, securityGroup.Spec.VpcReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.GetSecurityGroup(ctx, projectID, vpcID, resourceID)
+	if err != nil {
+		return err
+	}
+
+	securityGroup.Status.ProjectID = projectID
+	securityGroup.Status.VpcID = vpcID
+	return nil
+}
+
 func (r *SecurityGroupReconciler) Provisioning(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	securityGroup := obj.(*v1alpha1.SecurityGroup)
 	return r.HandleProvisioning(ctx, obj, status, func(ctx context.Context) (string, error) {
@@ -135,7 +196,7 @@ func (r *SecurityGroupReconciler) Updating(ctx context.Context, obj client.Objec
 		securityGroupReq := arubaClient.SecurityGroupRequest{
 			Metadata: arubaClient.SecurityGroupMetadata{
 				Name: securityGroup.Name,
-				Tags: securityGroup.Spec.Tags,
+				Tags: reconciler.OwnedTags(securityGroup, securityGroup.Spec.Tags),
 				Location: arubaClient.SecurityGroupLocation{
 					Value: securityGroup.Spec.Location.Value,
 				},
@@ -151,12 +212,30 @@ func (r *SecurityGroupReconciler) Updating(ctx context.Context, obj client.Objec
 }
 
 func (r *SecurityGroupReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
-	return r.CheckForUpdates(ctx, obj, status)
+	securityGroup := obj.(*v1alpha1.SecurityGroup)
+	return r.HandleCreated(ctx, obj, status, func(ctx context.Context) ([]string, error) {
+		securityGroupResp, err := r.GetSecurityGroup(ctx, securityGroup.Status.ProjectID, securityGroup.Status.VpcID, status.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		var driftedFields []string
+		if !util.SameElements(securityGroup.Spec.Tags, reconciler.WithoutOwnershipTag(securityGroupResp.Metadata.Tags)) {
+			driftedFields = append(driftedFields, "spec.tags")
+		}
+		if securityGroup.Spec.Default != securityGroupResp.Properties.Default {
+			driftedFields = append(driftedFields, "spec.default")
+		}
+		return driftedFields, nil
+	})
 }
 
 func (r *SecurityGroupReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	securityGroup := obj.(*v1alpha1.SecurityGroup)
 	return r.HandleDeletion(ctx, obj, status, securityGroupFinalizerName, func(ctx context.Context) error {
 		return r.DeleteSecurityGroup(ctx, securityGroup.Status.ProjectID, securityGroup.Status.VpcID, status.ResourceID)
+	}, func(ctx context.Context) error {
+		_, err := r.GetSecurityGroup(ctx, securityGroup.Status.ProjectID, securityGroup.Status.VpcID, status.ResourceID)
+		return err
 	})
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
 )
 
 // SecurityRuleReconciler reconciles a SecurityRule object
@@ -43,6 +44,8 @@ func NewSecurityRuleReconciler(reconciler *reconciler.Reconciler) *SecurityRuleR
 // +kubebuilder:rbac:groups=arubacloud.com,resources=securityrules/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=arubacloud.com,resources=securityrules/finalizers,verbs=update
 // +kubebuilder:rbac:groups=arubacloud.com,resources=projects,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=vpcs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=securitygroups,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
@@ -53,9 +56,33 @@ func (r *SecurityRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SecurityRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.SecurityRule{}, reconciler.ProjectReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.SecurityRule).Spec.ProjectReference}
+	}); err != nil {
+		return err
+	}
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.SecurityRule{}, reconciler.VpcReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.SecurityRule).Spec.VpcReference}
+	}); err != nil {
+		return err
+	}
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.SecurityRule{}, reconciler.SecurityGroupReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.SecurityRule).Spec.SecurityGroupReference}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.SecurityRule{}).
+		Watches(&v1alpha1.Project{}, r.EnqueueReferencing(&v1alpha1.SecurityRuleList{}, reconciler.ProjectReferenceIndex), reconciler.ReferencedStatusChanged()).
+		Watches(&v1alpha1.Vpc{}, r.EnqueueReferencing(&v1alpha1.SecurityRuleList{}, reconciler.VpcReferenceIndex), reconciler.ReferencedStatusChanged()).
+		Watches(&v1alpha1.SecurityGroup{}, r.EnqueueReferencing(&v1alpha1.SecurityRuleList{}, reconciler.SecurityGroupReferenceIndex), reconciler.ReferencedStatusChanged()).
 		Named("securityrule").
+		WithOptions(r.ControllerOptions()).
 		Complete(r)
 }
 
@@ -67,6 +94,16 @@ func (r *SecurityRuleReconciler) Init(ctx context.Context, obj client.Object, st
 	return r.InitializeResource(ctx, obj, status, securityRuleFinalizerName)
 }
 
+// Dependencies returns the resources that must be provisioned before the SecurityRule can be created or updated
+func (r *SecurityRuleReconciler) Dependencies(obj client.Object) []reconciler.Dependency {
+	securityRule := obj.(*v1alpha1.SecurityRule)
+	return []reconciler.Dependency{
+		{Kind: "Project", Reference: securityRule.Spec.ProjectReference},
+		{Kind: "Vpc", Reference: securityRule.Spec.VpcReference},
+		{Kind: "SecurityGroup", Reference: securityRule.Spec.SecurityGroupReference},
+	}
+}
+
 func (r *SecurityRuleReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	securityRule := obj.(*v1alpha1.SecurityRule)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
@@ -96,7 +133,7 @@ func (r *SecurityRuleReconciler) Creating(ctx context.Context, obj client.Object
 		securityRuleReq := arubaClient.SecurityRuleRequest{
 			Metadata: arubaClient.SecurityRuleMetadata{
 				Name: securityRule.Name,
-				Tags: securityRule.Spec.Tags,
+				Tags: reconciler.OwnedTags(securityRule, securityRule.Spec.Tags),
 				Location: arubaClient.SecurityRuleLocation{
 					Value: securityRule.Spec.Location.Value,
 				},
@@ -112,10 +149,20 @@ func (r *SecurityRuleReconciler) Creating(ctx context.Context, obj client.Object
 			},
 		}
 
-		securityRuleResp, err := r.CreateSecurityRule(ctx, projectID, vpcID, securityGroupID, securityRuleReq)
+		// Adopt a SecurityRule created by a previous attempt whose ID was never recorded
+		securityRuleList, err := r.ListSecurityRules(ctx, projectID, vpcID, securityGroupID)
 		if err != nil {
 			return "", "", err
 		}
+		securityRuleResp := reconciler.FindOwned(obj, securityRuleList.Values, func(remote *arubaClient.SecurityRuleResponse) []string {
+			return remote.Metadata.Tags
+		})
+		if securityRuleResp == nil {
+			securityRuleResp, err = r.CreateSecurityRule(ctx, projectID, vpcID, securityGroupID, securityRuleReq)
+			if err != nil {
+				return "", "", err
+			}
+		}
 
 		securityRule.Status.ProjectID = projectID
 		securityRule.Status.VpcID = vpcID
@@ -130,6 +177,35 @@ func (r *SecurityRuleReconciler) Creating(ctx context.Context, obj client.Object
 	})
 }
 
+// Import adopts an existing remote SecurityRule and records the IDs of its parent resources
+func (r *SecurityRuleReconciler) Import(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, resourceID string) error {
+	securityRule := obj.(*v1alpha1.SecurityRule)
+	projectID, err := r.GetProjectID(ctx, securityRule.Spec.ProjectReference.Name, securityRule.Spec.ProjectReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	vpcID, err := r.GetVpcID(ctx, securityRule.Spec.VpcReference.Name, securityRule.Spec.VpcReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	securityGroupID, err := r.GetSecurityGroupID(ctx, securityRule.Spec.SecurityGroupReference.Name, securityRule.Spec.SecurityGroupReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.GetSecurityRule(ctx, projectID, vpcID, securityGroupID, resourceID)
+	if err != nil {
+		return err
+	}
+
+	securityRule.Status.ProjectID = projectID
+	securityRule.Status.VpcID = vpcID
+	securityRule.Status.SecurityGroupID = securityGroupID
+	return nil
+}
+
 func (r *SecurityRuleReconciler) Provisioning(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	securityRule := obj.(*v1alpha1.SecurityRule)
 	return r.HandleProvisioning(ctx, obj, status, func(ctx context.Context) (string, error) {
@@ -151,7 +227,7 @@ func (r *SecurityRuleReconciler) Updating(ctx context.Context, obj client.Object
 		securityRuleReq := arubaClient.SecurityRuleRequest{
 			Metadata: arubaClient.SecurityRuleMetadata{
 				Name: securityRule.Name,
-				Tags: securityRule.Spec.Tags,
+				Tags: reconciler.OwnedTags(securityRule, securityRule.Spec.Tags),
 				Location: arubaClient.SecurityRuleLocation{
 					Value: securityRule.Spec.Location.Value,
 				},
@@ -173,12 +249,40 @@ func (r *SecurityRuleReconciler) Updating(ctx context.Context, obj client.Object
 }
 
 func (r *SecurityRuleReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
-	return r.CheckForUpdates(ctx, obj, status)
+	securityRule := obj.(*v1alpha1.SecurityRule)
+	return r.HandleCreated(ctx, obj, status, func(ctx context.Context) ([]string, error) {
+		securityRuleResp, err := r.GetSecurityRule(ctx, securityRule.Status.ProjectID, securityRule.Status.VpcID, securityRule.Status.SecurityGroupID, status.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		var driftedFields []string
+		if !util.SameElements(securityRule.Spec.Tags, reconciler.WithoutOwnershipTag(securityRuleResp.Metadata.Tags)) {
+			driftedFields = append(driftedFields, "spec.tags")
+		}
+		if securityRule.Spec.Protocol != securityRuleResp.Properties.Protocol {
+			driftedFields = append(driftedFields, "spec.protocol")
+		}
+		if securityRule.Spec.Port != securityRuleResp.Properties.Port {
+			driftedFields = append(driftedFields, "spec.port")
+		}
+		if securityRule.Spec.Direction != securityRuleResp.Properties.Direction {
+			driftedFields = append(driftedFields, "spec.direction")
+		}
+		if securityRule.Spec.Target.Kind != securityRuleResp.Properties.Target.Kind ||
+			securityRule.Spec.Target.Value != securityRuleResp.Properties.Target.Value {
+			driftedFields = append(driftedFields, "spec.target")
+		}
+		return driftedFields, nil
+	})
 }
 
 func (r *SecurityRuleReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	securityRule := obj.(*v1alpha1.SecurityRule)
 	return r.HandleDeletion(ctx, obj, status, securityRuleFinalizerName, func(ctx context.Context) error {
 		return r.DeleteSecurityRule(ctx, securityRule.Status.ProjectID, securityRule.Status.VpcID, securityRule.Status.SecurityGroupID, status.ResourceID)
+	}, func(ctx context.Context) error {
+		_, err := r.GetSecurityRule(ctx, securityRule.Status.ProjectID, securityRule.Status.VpcID, securityRule.Status.SecurityGroupID, status.ResourceID)
+		return err
 	})
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
 )
 
 // SubnetReconciler reconciles a Subnet object
@@ -43,6 +44,7 @@ func NewSubnetReconciler(reconciler *reconciler.Reconciler) *SubnetReconciler {
 // +kubebuilder:rbac:groups=arubacloud.com,resources=subnets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=arubacloud.com,resources=subnets/finalizers,verbs=update
 // +kubebuilder:rbac:groups=arubacloud.com,resources=projects,verbs=get;list;watch
+// +kubebuilder:rbac:groups=arubacloud.com,resources=vpcs,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
@@ -53,9 +55,26 @@ func (r *SubnetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SubnetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.Subnet{}, reconciler.ProjectReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.Subnet).Spec.ProjectReference}
+	}); err != nil {
+		return err
+	}
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.Subnet{}, reconciler.VpcReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.Subnet).Spec.VpcReference}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.Subnet{}).
+		Watches(&v1alpha1.Project{}, r.EnqueueReferencing(&v1alpha1.SubnetList{}, reconciler.ProjectReferenceIndex), reconciler.ReferencedStatusChanged()).
+		Watches(&v1alpha1.Vpc{}, r.EnqueueReferencing(&v1alpha1.SubnetList{}, reconciler.VpcReferenceIndex), reconciler.ReferencedStatusChanged()).
 		Named("subnet").
+		WithOptions(r.ControllerOptions()).
 		Complete(r)
 }
 
@@ -67,6 +86,15 @@ func (r *SubnetReconciler) Init(ctx context.Context, obj client.Object, status *
 	return r.InitializeResource(ctx, obj, status, subnetFinalizerName)
 }
 
+// Dependencies returns the resources that must be provisioned before the Subnet can be created or updated
+func (r *SubnetReconciler) Dependencies(obj client.Object) []reconciler.Dependency {
+	subnet := obj.(*v1alpha1.Subnet)
+	return []reconciler.Dependency{
+		{Kind: "Project", Reference: subnet.Spec.ProjectReference},
+		{Kind: "Vpc", Reference: subnet.Spec.VpcReference},
+	}
+}
+
 func (r *SubnetReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	subnet := obj.(*v1alpha1.Subnet)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
@@ -83,7 +111,7 @@ func (r *SubnetReconciler) Creating(ctx context.Context, obj client.Object, stat
 		subnetReq := arubaClient.SubnetRequest{
 			Metadata: arubaClient.SubnetMetadata{
 				Name: subnet.Name,
-				Tags: subnet.Spec.Tags,
+				Tags: reconciler.OwnedTags(subnet, subnet.Spec.Tags),
 			},
 			Properties: arubaClient.SubnetProperties{
 				Type:    subnet.Spec.Type,
@@ -97,10 +125,20 @@ func (r *SubnetReconciler) Creating(ctx context.Context, obj client.Object, stat
 			},
 		}
 
-		subnetResp, err := r.CreateSubnet(ctx, projectID, vpcID, subnetReq)
+		// Adopt a Subnet created by a previous attempt whose ID was never recorded
+		subnetList, err := r.ListSubnets(ctx, projectID, vpcID)
 		if err != nil {
 			return "", "", err
 		}
+		subnetResp := reconciler.FindOwned(obj, subnetList.Values, func(remote *arubaClient.SubnetResponse) []string {
+			return remote.Metadata.Tags
+		})
+		if subnetResp == nil {
+			subnetResp, err = r.CreateSubnet(ctx, projectID, vpcID, subnetReq)
+			if err != nil {
+				return "", "", err
+			}
+		}
 
 		subnet.Status.ProjectID = projectID
 		subnet.Status.VpcID = vpcID
@@ -114,6 +152,29 @@ func (r *SubnetReconciler) Creating(ctx context.Context, obj client.Object, stat
 	})
 }
 
+// Import adopts an existing remote Subnet and records the IDs of its parent resources
+func (r *SubnetReconciler) Import(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, resourceID string) error {
+	subnet := obj.(*v1alpha1.Subnet)
+	projectID, err := r.GetProjectID(ctx, subnet.Spec.ProjectReference.Name, subnet.Spec.ProjectReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	vpcID, err := r.GetVpcID(ctx, subnet.Spec.VpcReference.Name, subnet.Spec.VpcReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.GetSubnet(ctx, projectID, vpcID, resourceID)
+	if err != nil {
+		return err
+	}
+
+	subnet.Status.ProjectID = projectID
+	subnet.Status.VpcID = vpcID
+	return nil
+}
+
 func (r *SubnetReconciler) Provisioning(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	subnet := obj.(*v1alpha1.Subnet)
 	return r.HandleProvisioning(ctx, obj, status, func(ctx context.Context) (string, error) {
@@ -135,7 +196,7 @@ func (r *SubnetReconciler) Updating(ctx context.Context, obj client.Object, stat
 		subnetReq := arubaClient.SubnetRequest{
 			Metadata: arubaClient.SubnetMetadata{
 				Name: subnet.Name,
-				Tags: subnet.Spec.Tags,
+				Tags: reconciler.OwnedTags(subnet, subnet.Spec.Tags),
 			},
 			Properties: arubaClient.SubnetProperties{
 				Type:    subnet.Spec.Type,
@@ -155,12 +216,36 @@ func (r *SubnetReconciler) Updating(ctx context.Context, obj client.Object, stat
 }
 
 func (r *SubnetReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
-	return r.CheckForUpdates(ctx, obj, status)
+	subnet := obj.(*v1alpha1.Subnet)
+	return r.HandleCreated(ctx, obj, status, func(ctx context.Context) ([]string, error) {
+		subnetResp, err := r.GetSubnet(ctx, subnet.Status.ProjectID, subnet.Status.VpcID, status.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		var driftedFields []string
+		if !util.SameElements(subnet.Spec.Tags, reconciler.WithoutOwnershipTag(subnetResp.Metadata.Tags)) {
+			driftedFields = append(driftedFields, "spec.tags")
+		}
+		if subnet.Spec.Type != subnetResp.Properties.Type {
+			driftedFields = append(driftedFields, "spec.type")
+		}
+		if subnet.Spec.Network.Address != subnetResp.Properties.Network.Address {
+			driftedFields = append(driftedFields, "spec.network.address")
+		}
+		if subnet.Spec.DHCP.Enabled != subnetResp.Properties.DHCP.Enabled {
+			driftedFields = append(driftedFields, "spec.dhcp.enabled")
+		}
+		return driftedFields, nil
+	})
 }
 
 func (r *SubnetReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	subnet := obj.(*v1alpha1.Subnet)
 	return r.HandleDeletion(ctx, obj, status, subnetFinalizerName, func(ctx context.Context) error {
 		return r.DeleteSubnet(ctx, subnet.Status.ProjectID, subnet.Status.VpcID, status.ResourceID)
+	}, func(ctx context.Context) error {
+		_, err := r.GetSubnet(ctx, subnet.Status.ProjectID, subnet.Status.VpcID, status.ResourceID)
+		return err
 	})
 }
@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -127,5 +128,71 @@ var _ = Describe("Subnet Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("should wait for unresolved dependencies", func() {
+			By("Creating a subnet referencing a missing project and vpc")
+			waitingName := types.NamespacedName{
+				Name:      "test-subnet-waiting",
+				Namespace: "default",
+			}
+			waitingSubnet := &v1alpha1.Subnet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      waitingName.Name,
+					Namespace: waitingName.Namespace,
+				},
+				Spec: v1alpha1.SubnetSpec{
+					Tenant: "test-tenant",
+					Type:   "Advanced",
+					Network: v1alpha1.SubnetNetwork{
+						Address: "192.168.2.0/24",
+					},
+					VpcReference: v1alpha1.ResourceReference{
+						Name:      "missing-vpc",
+						Namespace: "default",
+					},
+					ProjectReference: v1alpha1.ResourceReference{
+						Name:      "missing-project",
+						Namespace: "default",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, waitingSubnet)).To(Succeed())
+
+			waitingSubnet.Status.Phase = v1alpha1.ResourcePhaseCreating
+			Expect(k8sClient.Status().Update(ctx, waitingSubnet)).To(Succeed())
+
+			auth := new(mocks.MockITokenManager)
+			auth.On("GetActiveToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("token 123", nil)
+
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			resourceReconciler := &SubnetReconciler{
+				Reconciler: &reconciler.Reconciler{
+					Client:       k8sClient,
+					Scheme:       k8sClient.Scheme(),
+					HelperClient: client.NewHelperClient(k8sClient, mockHTTPClient, "https://api.example.com"),
+					TokenManager: auth,
+				},
+			}
+
+			By("Reconciling the resource")
+			_, err := resourceReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: waitingName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			mockHTTPClient.AssertNotCalled(GinkgoT(), "Do", mock.Anything)
+
+			By("Verifying the subnet waits and lists every missing dependency")
+			updatedSubnet := &v1alpha1.Subnet{}
+			Expect(k8sClient.Get(ctx, waitingName, updatedSubnet)).To(Succeed())
+			Expect(updatedSubnet.Status.Phase).To(Equal(v1alpha1.ResourcePhaseWaiting))
+			dependenciesReady := meta.FindStatusCondition(updatedSubnet.Status.Conditions, v1alpha1.ConditionTypeDependenciesReady)
+			Expect(dependenciesReady).NotTo(BeNil())
+			Expect(dependenciesReady.Status).To(Equal(metav1.ConditionFalse))
+			Expect(dependenciesReady.Message).To(ContainSubstring("Project default/missing-project (NotFound)"))
+			Expect(dependenciesReady.Message).To(ContainSubstring("Vpc default/missing-vpc (NotFound)"))
+
+			By("Cleanup")
+			Expect(k8sClient.Delete(ctx, updatedSubnet)).To(Succeed())
+		})
 	})
 })
@@ -25,6 +25,7 @@ import (
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
 )
 
 // VpcReconciler reconciles a Vpc object
@@ -53,9 +54,19 @@ func (r *VpcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *VpcReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+
+	if err := reconciler.IndexReferences(ctx, mgr, &v1alpha1.Vpc{}, reconciler.ProjectReferenceIndex, func(obj client.Object) []v1alpha1.ResourceReference {
+		return []v1alpha1.ResourceReference{obj.(*v1alpha1.Vpc).Spec.ProjectReference}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.Vpc{}).
+		Watches(&v1alpha1.Project{}, r.EnqueueReferencing(&v1alpha1.VpcList{}, reconciler.ProjectReferenceIndex), reconciler.ReferencedStatusChanged()).
 		Named("vpc").
+		WithOptions(r.ControllerOptions()).
 		Complete(r)
 }
 
@@ -67,6 +78,14 @@ func (r *VpcReconciler) Init(ctx context.Context, obj client.Object, status *v1a
 	return r.InitializeResource(ctx, obj, status, vpcFinalizerName)
 }
 
+// Dependencies returns the resources that must be provisioned before the Vpc can be created or updated
+func (r *VpcReconciler) Dependencies(obj client.Object) []reconciler.Dependency {
+	vpc := obj.(*v1alpha1.Vpc)
+	return []reconciler.Dependency{
+		{Kind: "Project", Reference: vpc.Spec.ProjectReference},
+	}
+}
+
 func (r *VpcReconciler) Creating(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	vpc := obj.(*v1alpha1.Vpc)
 	return r.HandleCreating(ctx, obj, status, func(ctx context.Context) (string, string, error) {
@@ -82,7 +101,7 @@ func (r *VpcReconciler) Creating(ctx context.Context, obj client.Object, status
 		vpcReq := arubaClient.VpcRequest{
 			Metadata: arubaClient.VpcMetadata{
 				Name: vpc.Name,
-				Tags: vpc.Spec.Tags,
+				Tags: reconciler.OwnedTags(vpc, vpc.Spec.Tags),
 				Location: arubaClient.VpcLocation{
 					Value: vpc.Spec.Location.Value,
 				},
@@ -93,10 +112,20 @@ func (r *VpcReconciler) Creating(ctx context.Context, obj client.Object, status
 			},
 		}
 
-		vpcResp, err := r.CreateVpc(ctx, projectID, vpcReq)
+		// Adopt a Vpc created by a previous attempt whose ID was never recorded
+		vpcList, err := r.ListVpcs(ctx, projectID)
 		if err != nil {
 			return "", "", err
 		}
+		vpcResp := reconciler.FindOwned(obj, vpcList.Values, func(remote *arubaClient.VpcResponse) []string {
+			return remote.Metadata.Tags
+		})
+		if vpcResp == nil {
+			vpcResp, err = r.CreateVpc(ctx, projectID, vpcReq)
+			if err != nil {
+				return "", "", err
+			}
+		}
 
 		vpc.Status.ProjectID = projectID
 
@@ -109,6 +138,23 @@ func (r *VpcReconciler) Creating(ctx context.Context, obj client.Object, status
 	})
 }
 
+// Import adopts an existing remote Vpc and records the IDs of its parent resources
+func (r *VpcReconciler) Import(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, resourceID string) error {
+	vpc := obj.(*v1alpha1.Vpc)
+	projectID, err := r.GetProjectID(ctx, vpc.Spec.ProjectReference.Name, vpc.Spec.ProjectReference.Namespace)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.GetVpc(ctx, projectID, resourceID)
+	if err != nil {
+		return err
+	}
+
+	vpc.Status.ProjectID = projectID
+	return nil
+}
+
 func (r *VpcReconciler) Provisioning(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	vpc := obj.(*v1alpha1.Vpc)
 	return r.HandleProvisioning(ctx, obj, status, func(ctx context.Context) (string, error) {
@@ -130,7 +176,7 @@ func (r *VpcReconciler) Updating(ctx context.Context, obj client.Object, status
 		vpcReq := arubaClient.VpcRequest{
 			Metadata: arubaClient.VpcMetadata{
 				Name: vpc.Name,
-				Tags: vpc.Spec.Tags,
+				Tags: reconciler.OwnedTags(vpc, vpc.Spec.Tags),
 				Location: arubaClient.VpcLocation{
 					Value: vpc.Spec.Location.Value,
 				},
@@ -143,12 +189,27 @@ func (r *VpcReconciler) Updating(ctx context.Context, obj client.Object, status
 }
 
 func (r *VpcReconciler) Created(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
-	return r.CheckForUpdates(ctx, obj, status)
+	vpc := obj.(*v1alpha1.Vpc)
+	return r.HandleCreated(ctx, obj, status, func(ctx context.Context) ([]string, error) {
+		vpcResp, err := r.GetVpc(ctx, vpc.Status.ProjectID, status.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		var driftedFields []string
+		if !util.SameElements(vpc.Spec.Tags, reconciler.WithoutOwnershipTag(vpcResp.Metadata.Tags)) {
+			driftedFields = append(driftedFields, "spec.tags")
+		}
+		return driftedFields, nil
+	})
 }
 
 func (r *VpcReconciler) Deleting(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
 	vpc := obj.(*v1alpha1.Vpc)
 	return r.HandleDeletion(ctx, obj, status, vpcFinalizerName, func(ctx context.Context) error {
 		return r.DeleteVpc(ctx, vpc.Status.ProjectID, status.ResourceID)
+	}, func(ctx context.Context) error {
+		_, err := r.GetVpc(ctx, vpc.Status.ProjectID, status.ResourceID)
+		return err
 	})
 }
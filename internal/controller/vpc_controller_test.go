@@ -28,7 +28,9 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -118,5 +120,148 @@ var _ = Describe("Vpc Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("should keep the finalizer until the remote vpc is gone", func() {
+			By("Creating a vpc being deleted")
+			deletingName := types.NamespacedName{
+				Name:      "test-vpc-deleting",
+				Namespace: "default",
+			}
+			deletingVpc := &v1alpha1.Vpc{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       deletingName.Name,
+					Namespace:  deletingName.Namespace,
+					Finalizers: []string{vpcFinalizerName},
+				},
+				Spec: v1alpha1.VpcSpec{
+					Tenant: "test-tenant",
+					Location: v1alpha1.Location{
+						Value: "ITBG-Bergamo",
+					},
+					ProjectReference: v1alpha1.ResourceReference{
+						Name:      "test-project",
+						Namespace: "default",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deletingVpc)).To(Succeed())
+
+			now := metav1.Now()
+			deletingVpc.Status.ResourceStatus = v1alpha1.ResourceStatus{
+				Phase:          v1alpha1.ResourcePhaseDeleting,
+				PhaseStartTime: &now,
+				ResourceID:     "vpc-123",
+			}
+			deletingVpc.Status.ProjectID = "project-123"
+			Expect(k8sClient.Status().Update(ctx, deletingVpc)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, deletingVpc)).To(Succeed())
+
+			auth := new(mocks.MockITokenManager)
+			auth.On("GetActiveToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("token 123", nil)
+
+			// DELETE is accepted, then the remote vpc is reported as gone
+			mockHTTPClient := new(mocks.MockHTTPClient)
+			mockHTTPClient.On("Do", mock.AnythingOfType("*http.Request")).Return(
+				func(req *http.Request) (*http.Response, error) {
+					statusCode := http.StatusAccepted
+					if req.Method == http.MethodGet {
+						statusCode = http.StatusNotFound
+					}
+					return &http.Response{
+						StatusCode: statusCode,
+						Body:       io.NopCloser(strings.NewReader("")),
+						Header:     make(http.Header),
+					}, nil
+				}, nil)
+
+			recorder := record.NewFakeRecorder(10)
+			resourceReconciler := &VpcReconciler{
+				Reconciler: &reconciler.Reconciler{
+					Client:       k8sClient,
+					Scheme:       k8sClient.Scheme(),
+					TokenManager: auth,
+					HelperClient: arubaClient.NewHelperClient(k8sClient, mockHTTPClient, "https://api.example.com"),
+					Recorder:     recorder,
+				},
+			}
+
+			By("Reconciling sends the DELETE and keeps the finalizer")
+			_, err := resourceReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: deletingName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedVpc := &v1alpha1.Vpc{}
+			Expect(k8sClient.Get(ctx, deletingName, updatedVpc)).To(Succeed())
+			Expect(updatedVpc.Finalizers).To(ContainElement(vpcFinalizerName))
+			Expect(meta.IsStatusConditionTrue(updatedVpc.Status.Conditions, v1alpha1.ConditionTypeDeletionInProgress)).To(BeTrue())
+
+			By("Reconciling again removes the finalizer once the remote vpc returns 404")
+			_, err = resourceReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: deletingName})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, deletingName, updatedVpc)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(recorder.Events).To(Receive(Equal("Normal Deleted Remote resource deleted")))
+		})
+
+		It("should orphan the remote vpc when the deletion policy is Orphan", func() {
+			By("Creating an orphaned vpc being deleted")
+			orphanName := types.NamespacedName{
+				Name:      "test-vpc-orphan",
+				Namespace: "default",
+			}
+			orphanVpc := &v1alpha1.Vpc{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        orphanName.Name,
+					Namespace:   orphanName.Namespace,
+					Finalizers:  []string{vpcFinalizerName},
+					Annotations: map[string]string{v1alpha1.AnnotationDeletionPolicy: string(v1alpha1.DeletionPolicyOrphan)},
+				},
+				Spec: v1alpha1.VpcSpec{
+					Tenant: "test-tenant",
+					Location: v1alpha1.Location{
+						Value: "ITBG-Bergamo",
+					},
+					ProjectReference: v1alpha1.ResourceReference{
+						Name:      "test-project",
+						Namespace: "default",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, orphanVpc)).To(Succeed())
+
+			now := metav1.Now()
+			orphanVpc.Status.ResourceStatus = v1alpha1.ResourceStatus{
+				Phase:          v1alpha1.ResourcePhaseDeleting,
+				PhaseStartTime: &now,
+				ResourceID:     "vpc-123",
+			}
+			orphanVpc.Status.ProjectID = "project-123"
+			Expect(k8sClient.Status().Update(ctx, orphanVpc)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, orphanVpc)).To(Succeed())
+
+			auth := new(mocks.MockITokenManager)
+			auth.On("GetActiveToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("token 123", nil)
+			mockHTTPClient := new(mocks.MockHTTPClient)
+
+			recorder := record.NewFakeRecorder(10)
+			resourceReconciler := &VpcReconciler{
+				Reconciler: &reconciler.Reconciler{
+					Client:       k8sClient,
+					Scheme:       k8sClient.Scheme(),
+					TokenManager: auth,
+					HelperClient: arubaClient.NewHelperClient(k8sClient, mockHTTPClient, "https://api.example.com"),
+					Recorder:     recorder,
+				},
+			}
+
+			By("Reconciling removes the finalizer without calling the API")
+			_, err := resourceReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: orphanName})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, orphanName, &v1alpha1.Vpc{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			mockHTTPClient.AssertNotCalled(GinkgoT(), "Do", mock.Anything)
+			Expect(recorder.Events).To(Receive(Equal("Normal Deleted Resource deleted, remote resource vpc-123 orphaned")))
+		})
 	})
 })
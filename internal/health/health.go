@@ -0,0 +1,45 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+const (
+	// DefaultCacheTTL is how long the result of a check is served before the check runs again
+	DefaultCacheTTL = 30 * time.Second
+	// checkTimeout bounds the time spent by a single check
+	checkTimeout = 5 * time.Second
+)
+
+// Check confirms that a dependency of the operator is working
+type Check func(ctx context.Context) error
+
+// Cached turns check into a healthz.Checker that runs it at most once per ttl, so that
+// frequent probes do not put load on the checked services. Concurrent probes wait for
+// the running check and share its result.
+func Cached(ttl time.Duration, check Check) healthz.Checker {
+	var (
+		mu        sync.Mutex
+		checkedAt time.Time
+		lastErr   error
+	)
+	return func(req *http.Request) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !checkedAt.IsZero() && time.Since(checkedAt) < ttl {
+			return lastErr
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), checkTimeout)
+		defer cancel()
+		lastErr = check(ctx)
+		checkedAt = time.Now()
+		return lastErr
+	}
+}
@@ -0,0 +1,42 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/health"
+)
+
+func TestCached(t *testing.T) {
+	calls := 0
+	checkErr := errors.New("unavailable")
+	checker := health.Cached(time.Hour, func(ctx context.Context) error {
+		calls++
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return checkErr
+	})
+
+	req := httptest.NewRequest("GET", "/readyz", nil)
+	assert.ErrorIs(t, checker(req), checkErr)
+	assert.ErrorIs(t, checker(req), checkErr)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCached_Expired(t *testing.T) {
+	calls := 0
+	checker := health.Cached(0, func(_ context.Context) error {
+		calls++
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/readyz", nil)
+	assert.NoError(t, checker(req))
+	assert.NoError(t, checker(req))
+	assert.Equal(t, 2, calls)
+}
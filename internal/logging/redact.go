@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the values masked in the logs
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are the normalized keys (lowercase, without separators) whose values are never logged
+var sensitiveKeys = map[string]bool{
+	"accesstoken":   true,
+	"apikey":        true,
+	"authorization": true,
+	"clientsecret":  true,
+	"clienttoken":   true,
+	"password":      true,
+	"privatekey":    true,
+	"refreshtoken":  true,
+	"rolesecret":    true,
+	"secret":        true,
+	"secretdata":    true,
+	"secretid":      true,
+	"token":         true,
+}
+
+// jwtPattern matches JWT-shaped values: three base64url segments, the first one a JSON header
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// RedactAttr masks the value of sensitive attributes and the JWTs found in any other value.
+// It has the signature of slog.HandlerOptions.ReplaceAttr so it can sit in the logging path.
+func RedactAttr(_ []string, a slog.Attr) slog.Attr {
+	if isSensitive(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(redactString(a.Value.String()))
+	case slog.KindAny:
+		a.Value = slog.AnyValue(redactAny(a.Value.Any()))
+	}
+	return a
+}
+
+// isSensitive reports whether key names a secret, ignoring case and separators
+func isSensitive(key string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "", ".", "", " ", "").Replace(strings.ToLower(key))
+	return sensitiveKeys[normalized]
+}
+
+func redactString(s string) string {
+	return jwtPattern.ReplaceAllString(s, Redacted)
+}
+
+// Redact returns a copy of v with its sensitive fields and JWTs masked, for values logged
+// outside the handler, e.g. API bodies
+func Redact(v any) any {
+	return redactAny(v)
+}
+
+// redactAny masks a structured value. Values other than maps, slices and errors are converted
+// through JSON so the fields of structs (e.g. a Vault secret) are redacted by name too.
+func redactAny(v any) any {
+	switch value := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return redactString(value)
+	case error:
+		return redactString(value.Error())
+	case map[string]any:
+		redacted := make(map[string]any, len(value))
+		for key, item := range value {
+			if isSensitive(key) {
+				redacted[key] = Redacted
+				continue
+			}
+			redacted[key] = redactAny(item)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(value))
+		for i, item := range value {
+			redacted[i] = redactAny(item)
+		}
+		return redacted
+	case bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return value
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return v
+	}
+	return redactAny(generic)
+}
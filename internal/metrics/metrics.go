@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "arubacloud"
+
+// Results used as label values by the token and Vault counters
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+var (
+	// APIRequests counts the requests sent to the Aruba API gateway
+	APIRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "api",
+		Name:      "requests_total",
+		Help:      "Number of requests sent to the Aruba API, by method, path template and status code.",
+	}, []string{"method", "path", "code"})
+
+	// APIRequestDuration observes the latency of the requests sent to the Aruba API gateway
+	APIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "api",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the requests sent to the Aruba API, by method, path template and status code.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "path", "code"})
+
+	// APIAvailable reports whether the circuit breaker of each Aruba API gateway is closed
+	APIAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "api",
+		Name:      "available",
+		Help:      "Whether the Aruba API gateway is available (1) or the circuit breaker is open (0), by gateway.",
+	}, []string{"gateway"})
+
+	// PhaseTimeouts counts the resources moved to Failed because a phase took too long
+	PhaseTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "reconcile",
+		Name:      "phase_timeouts_total",
+		Help:      "Number of phase timeouts, by kind and timed out phase.",
+	}, []string{"kind", "phase"})
+
+	// FailedTransitions counts the transitions of resources to the Failed phase
+	FailedTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "reconcile",
+		Name:      "failed_transitions_total",
+		Help:      "Number of transitions to the Failed phase, by kind and reason.",
+	}, []string{"kind", "reason"})
+
+	// TokenFetches counts the access tokens requested to Keycloak
+	TokenFetches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "keycloak",
+		Name:      "token_fetches_total",
+		Help:      "Number of access tokens requested to Keycloak, by result.",
+	}, []string{"result"})
+
+	// TokenRefreshes counts the access tokens renewed with a refresh token
+	TokenRefreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "keycloak",
+		Name:      "token_refreshes_total",
+		Help:      "Number of access tokens renewed with a refresh token, by result.",
+	}, []string{"result"})
+
+	// VaultRenewals counts the renewals of the Vault token
+	VaultRenewals = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "vault",
+		Name:      "token_renewals_total",
+		Help:      "Number of Vault token renewals, by result.",
+	}, []string{"result"})
+
+	// VaultLogins counts the logins to Vault, including the re-logins after a failed renewal
+	VaultLogins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "vault",
+		Name:      "logins_total",
+		Help:      "Number of Vault logins, by result.",
+	}, []string{"result"})
+
+	// ConfigLoaded reports whether the last change to the operator configuration was applied
+	ConfigLoaded = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "config",
+		Name:      "loaded",
+		Help:      "Whether the last version of the operator ConfigMap and Secret was loaded (1) or rejected (0).",
+	})
+
+	// ConfigReloads counts the reloads of the operator configuration
+	ConfigReloads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config",
+		Name:      "reloads_total",
+		Help:      "Number of reloads of the operator configuration, by result.",
+	}, []string{"result"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		APIRequests,
+		APIRequestDuration,
+		APIAvailable,
+		PhaseTimeouts,
+		FailedTransitions,
+		TokenFetches,
+		TokenRefreshes,
+		VaultRenewals,
+		VaultLogins,
+		ConfigLoaded,
+		ConfigReloads,
+	)
+}
+
+// ObserveAPIRequest records a request to the Aruba API. A zero code means no response was received.
+func ObserveAPIRequest(method, endpoint string, code int, duration time.Duration) {
+	codeLabel := "error"
+	if code != 0 {
+		codeLabel = strconv.Itoa(code)
+	}
+	path := PathTemplate(endpoint)
+	APIRequests.WithLabelValues(method, path, codeLabel).Inc()
+	APIRequestDuration.WithLabelValues(method, path, codeLabel).Observe(duration.Seconds())
+}
+
+// ResultLabel returns the result label value for err
+func ResultLabel(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultSuccess
+}
+
+// PathTemplate turns an API endpoint into a low cardinality label: the query is dropped and
+// everything from the provider on is kept with the resource IDs replaced, e.g.
+// /projects/p1/providers/Aruba.Network/vpcs/v1/subnets becomes Aruba.Network/vpcs/{id}/subnets
+func PathTemplate(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil {
+		endpoint = u.Path
+	}
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+
+	// Without a provider the path is made of collections of the gateway itself (e.g. projects)
+	start := 0
+	for i, segment := range segments {
+		if segment == "providers" && i+1 < len(segments) {
+			start = i + 1
+			break
+		}
+	}
+
+	template := make([]string, 0, len(segments)-start)
+	if start > 0 {
+		template = append(template, segments[start])
+		start++
+	}
+	// Collections and IDs alternate after the provider
+	for i, segment := range segments[start:] {
+		if i%2 == 1 {
+			segment = "{id}"
+		}
+		template = append(template, segment)
+	}
+	return strings.Join(template, "/")
+}
@@ -0,0 +1,25 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/metrics"
+)
+
+func TestPathTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/projects":    "projects",
+		"/projects/p1": "projects/{id}",
+		"/projects/p1/providers/Aruba.Compute/cloudServers?api-version=1.1":                 "Aruba.Compute/cloudServers",
+		"/projects/p1/providers/Aruba.Compute/cloudServers/cs1/attachDetachDataVolumes":     "Aruba.Compute/cloudServers/{id}/attachDetachDataVolumes",
+		"/projects/p1/providers/Aruba.Network/vpcs/v1/securityGroups/sg1/securityRules/sr1": "Aruba.Network/vpcs/{id}/securityGroups/{id}/securityRules/{id}",
+	}
+
+	for endpoint, expected := range tests {
+		t.Run(endpoint, func(t *testing.T) {
+			assert.Equal(t, expected, metrics.PathTemplate(endpoint))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// collectTimeout bounds the time spent listing resources on each scrape
+const collectTimeout = 5 * time.Second
+
+var resourcesDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "resources"),
+	"Number of resources, by kind, phase and tenant.",
+	[]string{"kind", "phase", "tenant"},
+	nil,
+)
+
+// phaseCollector counts the managed resources per phase when scraped,
+// reading them from the informer cache so the gauge never drifts from the cluster
+type phaseCollector struct {
+	reader client.Reader
+}
+
+// RegisterPhaseCollector registers the gauge of resources per kind, phase and tenant
+func RegisterPhaseCollector(mgr ctrl.Manager) error {
+	return ctrlmetrics.Registry.Register(&phaseCollector{
+		reader: mgr.GetCache(),
+	})
+}
+
+// managedLists returns empty lists of the kinds reconciled through phases, by kind.
+// Kinds without phase, such as ProviderConfig, are not counted.
+func managedLists() map[string]client.ObjectList {
+	return map[string]client.ObjectList{
+		"Project":       &v1alpha1.ProjectList{},
+		"Vpc":           &v1alpha1.VpcList{},
+		"Subnet":        &v1alpha1.SubnetList{},
+		"SecurityGroup": &v1alpha1.SecurityGroupList{},
+		"SecurityRule":  &v1alpha1.SecurityRuleList{},
+		"ElasticIp":     &v1alpha1.ElasticIpList{},
+		"KeyPair":       &v1alpha1.KeyPairList{},
+		"BlockStorage":  &v1alpha1.BlockStorageList{},
+		"CloudServer":   &v1alpha1.CloudServerList{},
+	}
+}
+
+func (c *phaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- resourcesDesc
+}
+
+func (c *phaseCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	for kind, list := range managedLists() {
+		// Fails until the cache is started, the next scrape will catch up
+		if err := c.reader.List(ctx, list); err != nil {
+			continue
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			continue
+		}
+		counts := make(map[[2]string]int)
+		for _, item := range items {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(item)
+			if err != nil {
+				continue
+			}
+			phase, _, _ := unstructured.NestedString(content, "status", "phase")
+			tenant, _, _ := unstructured.NestedString(content, "spec", "tenant")
+			if phase == "" {
+				// Same label the reconciler logs for resources not initialized yet
+				phase = "Initializing"
+			}
+			counts[[2]string{phase, tenant}]++
+		}
+		for key, count := range counts {
+			ch <- prometheus.MustNewConstMetric(resourcesDesc, prometheus.GaugeValue, float64(count), kind, key[0], key[1])
+		}
+	}
+}
@@ -23,6 +23,64 @@ func (_m *MockAuthTokenAPI) EXPECT() *MockAuthTokenAPI_Expecter {
 	return &MockAuthTokenAPI_Expecter{mock: &_m.Mock}
 }
 
+// LookupSelfWithContext provides a mock function with given fields: ctx
+func (_m *MockAuthTokenAPI) LookupSelfWithContext(ctx context.Context) (*api.Secret, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupSelfWithContext")
+	}
+
+	var r0 *api.Secret
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*api.Secret, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *api.Secret); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.Secret)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthTokenAPI_LookupSelfWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupSelfWithContext'
+type MockAuthTokenAPI_LookupSelfWithContext_Call struct {
+	*mock.Call
+}
+
+// LookupSelfWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAuthTokenAPI_Expecter) LookupSelfWithContext(ctx interface{}) *MockAuthTokenAPI_LookupSelfWithContext_Call {
+	return &MockAuthTokenAPI_LookupSelfWithContext_Call{Call: _e.mock.On("LookupSelfWithContext", ctx)}
+}
+
+func (_c *MockAuthTokenAPI_LookupSelfWithContext_Call) Run(run func(ctx context.Context)) *MockAuthTokenAPI_LookupSelfWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockAuthTokenAPI_LookupSelfWithContext_Call) Return(_a0 *api.Secret, _a1 error) *MockAuthTokenAPI_LookupSelfWithContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthTokenAPI_LookupSelfWithContext_Call) RunAndReturn(run func(context.Context) (*api.Secret, error)) *MockAuthTokenAPI_LookupSelfWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RenewSelfWithContext provides a mock function with given fields: ctx, increment
 func (_m *MockAuthTokenAPI) RenewSelfWithContext(ctx context.Context, increment int) (*api.Secret, error) {
 	ret := _m.Called(ctx, increment)
@@ -0,0 +1,94 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	client "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCredentialsFunc is an autogenerated mock type for the CredentialsFunc type
+type MockCredentialsFunc struct {
+	mock.Mock
+}
+
+type MockCredentialsFunc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCredentialsFunc) EXPECT() *MockCredentialsFunc_Expecter {
+	return &MockCredentialsFunc_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, tenant
+func (_m *MockCredentialsFunc) Execute(ctx context.Context, tenant string) (client.Credentials, error) {
+	ret := _m.Called(ctx, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 client.Credentials
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (client.Credentials, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) client.Credentials); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		r0 = ret.Get(0).(client.Credentials)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCredentialsFunc_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockCredentialsFunc_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+func (_e *MockCredentialsFunc_Expecter) Execute(ctx interface{}, tenant interface{}) *MockCredentialsFunc_Execute_Call {
+	return &MockCredentialsFunc_Execute_Call{Call: _e.mock.On("Execute", ctx, tenant)}
+}
+
+func (_c *MockCredentialsFunc_Execute_Call) Run(run func(ctx context.Context, tenant string)) *MockCredentialsFunc_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCredentialsFunc_Execute_Call) Return(_a0 client.Credentials, _a1 error) *MockCredentialsFunc_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCredentialsFunc_Execute_Call) RunAndReturn(run func(context.Context, string) (client.Credentials, error)) *MockCredentialsFunc_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCredentialsFunc creates a new instance of MockCredentialsFunc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCredentialsFunc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCredentialsFunc {
+	mock := &MockCredentialsFunc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
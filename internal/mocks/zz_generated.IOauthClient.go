@@ -22,6 +22,65 @@ func (_m *MockIOauthClient) EXPECT() *MockIOauthClient_Expecter {
 	return &MockIOauthClient_Expecter{mock: &_m.Mock}
 }
 
+// GetIssuer provides a mock function with given fields: ctx, realm
+func (_m *MockIOauthClient) GetIssuer(ctx context.Context, realm string) (*gocloak.IssuerResponse, error) {
+	ret := _m.Called(ctx, realm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIssuer")
+	}
+
+	var r0 *gocloak.IssuerResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*gocloak.IssuerResponse, error)); ok {
+		return rf(ctx, realm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gocloak.IssuerResponse); ok {
+		r0 = rf(ctx, realm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocloak.IssuerResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, realm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIOauthClient_GetIssuer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIssuer'
+type MockIOauthClient_GetIssuer_Call struct {
+	*mock.Call
+}
+
+// GetIssuer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+func (_e *MockIOauthClient_Expecter) GetIssuer(ctx interface{}, realm interface{}) *MockIOauthClient_GetIssuer_Call {
+	return &MockIOauthClient_GetIssuer_Call{Call: _e.mock.On("GetIssuer", ctx, realm)}
+}
+
+func (_c *MockIOauthClient_GetIssuer_Call) Run(run func(ctx context.Context, realm string)) *MockIOauthClient_GetIssuer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIOauthClient_GetIssuer_Call) Return(_a0 *gocloak.IssuerResponse, _a1 error) *MockIOauthClient_GetIssuer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIOauthClient_GetIssuer_Call) RunAndReturn(run func(context.Context, string) (*gocloak.IssuerResponse, error)) *MockIOauthClient_GetIssuer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LoginClient provides a mock function with given fields: ctx, clientID, clientSecret, realm, options
 func (_m *MockIOauthClient) LoginClient(ctx context.Context, clientID string, clientSecret string, realm string, options ...string) (*gocloak.JWT, error) {
 	_va := make([]interface{}, len(options))
@@ -98,6 +157,68 @@ func (_c *MockIOauthClient_LoginClient_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// RefreshToken provides a mock function with given fields: ctx, refreshToken, clientID, clientSecret, realm
+func (_m *MockIOauthClient) RefreshToken(ctx context.Context, refreshToken string, clientID string, clientSecret string, realm string) (*gocloak.JWT, error) {
+	ret := _m.Called(ctx, refreshToken, clientID, clientSecret, realm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshToken")
+	}
+
+	var r0 *gocloak.JWT
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*gocloak.JWT, error)); ok {
+		return rf(ctx, refreshToken, clientID, clientSecret, realm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *gocloak.JWT); ok {
+		r0 = rf(ctx, refreshToken, clientID, clientSecret, realm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocloak.JWT)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, refreshToken, clientID, clientSecret, realm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIOauthClient_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
+type MockIOauthClient_RefreshToken_Call struct {
+	*mock.Call
+}
+
+// RefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refreshToken string
+//   - clientID string
+//   - clientSecret string
+//   - realm string
+func (_e *MockIOauthClient_Expecter) RefreshToken(ctx interface{}, refreshToken interface{}, clientID interface{}, clientSecret interface{}, realm interface{}) *MockIOauthClient_RefreshToken_Call {
+	return &MockIOauthClient_RefreshToken_Call{Call: _e.mock.On("RefreshToken", ctx, refreshToken, clientID, clientSecret, realm)}
+}
+
+func (_c *MockIOauthClient_RefreshToken_Call) Run(run func(ctx context.Context, refreshToken string, clientID string, clientSecret string, realm string)) *MockIOauthClient_RefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockIOauthClient_RefreshToken_Call) Return(_a0 *gocloak.JWT, _a1 error) *MockIOauthClient_RefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIOauthClient_RefreshToken_Call) RunAndReturn(run func(context.Context, string, string, string, string) (*gocloak.JWT, error)) *MockIOauthClient_RefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockIOauthClient creates a new instance of MockIOauthClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockIOauthClient(t interface {
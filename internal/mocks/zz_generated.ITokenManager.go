@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	client "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,9 +22,42 @@ func (_m *MockITokenManager) EXPECT() *MockITokenManager_Expecter {
 	return &MockITokenManager_Expecter{mock: &_m.Mock}
 }
 
-// GetAccessToken provides a mock function with given fields: checkCache, tenant
-func (_m *MockITokenManager) GetAccessToken(checkCache bool, tenant string) (string, error) {
-	ret := _m.Called(checkCache, tenant)
+// ExpireToken provides a mock function with given fields: tenant
+func (_m *MockITokenManager) ExpireToken(tenant string) {
+	_m.Called(tenant)
+}
+
+// MockITokenManager_ExpireToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireToken'
+type MockITokenManager_ExpireToken_Call struct {
+	*mock.Call
+}
+
+// ExpireToken is a helper method to define mock.On call
+//   - tenant string
+func (_e *MockITokenManager_Expecter) ExpireToken(tenant interface{}) *MockITokenManager_ExpireToken_Call {
+	return &MockITokenManager_ExpireToken_Call{Call: _e.mock.On("ExpireToken", tenant)}
+}
+
+func (_c *MockITokenManager_ExpireToken_Call) Run(run func(tenant string)) *MockITokenManager_ExpireToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockITokenManager_ExpireToken_Call) Return() *MockITokenManager_ExpireToken_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockITokenManager_ExpireToken_Call) RunAndReturn(run func(string)) *MockITokenManager_ExpireToken_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetAccessToken provides a mock function with given fields: ctx, checkCache, tenant
+func (_m *MockITokenManager) GetAccessToken(ctx context.Context, checkCache bool, tenant string) (string, error) {
+	ret := _m.Called(ctx, checkCache, tenant)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAccessToken")
@@ -30,17 +65,17 @@ func (_m *MockITokenManager) GetAccessToken(checkCache bool, tenant string) (str
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(bool, string) (string, error)); ok {
-		return rf(checkCache, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string) (string, error)); ok {
+		return rf(ctx, checkCache, tenant)
 	}
-	if rf, ok := ret.Get(0).(func(bool, string) string); ok {
-		r0 = rf(checkCache, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string) string); ok {
+		r0 = rf(ctx, checkCache, tenant)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(bool, string) error); ok {
-		r1 = rf(checkCache, tenant)
+	if rf, ok := ret.Get(1).(func(context.Context, bool, string) error); ok {
+		r1 = rf(ctx, checkCache, tenant)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,15 +89,16 @@ type MockITokenManager_GetAccessToken_Call struct {
 }
 
 // GetAccessToken is a helper method to define mock.On call
+//   - ctx context.Context
 //   - checkCache bool
 //   - tenant string
-func (_e *MockITokenManager_Expecter) GetAccessToken(checkCache interface{}, tenant interface{}) *MockITokenManager_GetAccessToken_Call {
-	return &MockITokenManager_GetAccessToken_Call{Call: _e.mock.On("GetAccessToken", checkCache, tenant)}
+func (_e *MockITokenManager_Expecter) GetAccessToken(ctx interface{}, checkCache interface{}, tenant interface{}) *MockITokenManager_GetAccessToken_Call {
+	return &MockITokenManager_GetAccessToken_Call{Call: _e.mock.On("GetAccessToken", ctx, checkCache, tenant)}
 }
 
-func (_c *MockITokenManager_GetAccessToken_Call) Run(run func(checkCache bool, tenant string)) *MockITokenManager_GetAccessToken_Call {
+func (_c *MockITokenManager_GetAccessToken_Call) Run(run func(ctx context.Context, checkCache bool, tenant string)) *MockITokenManager_GetAccessToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(bool), args[1].(string))
+		run(args[0].(context.Context), args[1].(bool), args[2].(string))
 	})
 	return _c
 }
@@ -72,7 +108,7 @@ func (_c *MockITokenManager_GetAccessToken_Call) Return(_a0 string, _a1 error) *
 	return _c
 }
 
-func (_c *MockITokenManager_GetAccessToken_Call) RunAndReturn(run func(bool, string) (string, error)) *MockITokenManager_GetAccessToken_Call {
+func (_c *MockITokenManager_GetAccessToken_Call) RunAndReturn(run func(context.Context, bool, string) (string, error)) *MockITokenManager_GetAccessToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -123,6 +159,97 @@ func (_c *MockITokenManager_GetActiveToken_Call) RunAndReturn(run func(string) s
 	return _c
 }
 
+// GetTenantToken provides a mock function with given fields: ctx, tenant, lookup
+func (_m *MockITokenManager) GetTenantToken(ctx context.Context, tenant string, lookup client.CredentialsFunc) (string, error) {
+	ret := _m.Called(ctx, tenant, lookup)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTenantToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, client.CredentialsFunc) (string, error)); ok {
+		return rf(ctx, tenant, lookup)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, client.CredentialsFunc) string); ok {
+		r0 = rf(ctx, tenant, lookup)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, client.CredentialsFunc) error); ok {
+		r1 = rf(ctx, tenant, lookup)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITokenManager_GetTenantToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTenantToken'
+type MockITokenManager_GetTenantToken_Call struct {
+	*mock.Call
+}
+
+// GetTenantToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - lookup client.CredentialsFunc
+func (_e *MockITokenManager_Expecter) GetTenantToken(ctx interface{}, tenant interface{}, lookup interface{}) *MockITokenManager_GetTenantToken_Call {
+	return &MockITokenManager_GetTenantToken_Call{Call: _e.mock.On("GetTenantToken", ctx, tenant, lookup)}
+}
+
+func (_c *MockITokenManager_GetTenantToken_Call) Run(run func(ctx context.Context, tenant string, lookup client.CredentialsFunc)) *MockITokenManager_GetTenantToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(client.CredentialsFunc))
+	})
+	return _c
+}
+
+func (_c *MockITokenManager_GetTenantToken_Call) Return(_a0 string, _a1 error) *MockITokenManager_GetTenantToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITokenManager_GetTenantToken_Call) RunAndReturn(run func(context.Context, string, client.CredentialsFunc) (string, error)) *MockITokenManager_GetTenantToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Invalidate provides a mock function with given fields: tenant
+func (_m *MockITokenManager) Invalidate(tenant string) {
+	_m.Called(tenant)
+}
+
+// MockITokenManager_Invalidate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invalidate'
+type MockITokenManager_Invalidate_Call struct {
+	*mock.Call
+}
+
+// Invalidate is a helper method to define mock.On call
+//   - tenant string
+func (_e *MockITokenManager_Expecter) Invalidate(tenant interface{}) *MockITokenManager_Invalidate_Call {
+	return &MockITokenManager_Invalidate_Call{Call: _e.mock.On("Invalidate", tenant)}
+}
+
+func (_c *MockITokenManager_Invalidate_Call) Run(run func(tenant string)) *MockITokenManager_Invalidate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockITokenManager_Invalidate_Call) Return() *MockITokenManager_Invalidate_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockITokenManager_Invalidate_Call) RunAndReturn(run func(string)) *MockITokenManager_Invalidate_Call {
+	_c.Run(run)
+	return _c
+}
+
 // IsExpiredHelper provides a mock function with given fields: cToken
 func (_m *MockITokenManager) IsExpiredHelper(cToken *client.CachedToken) bool {
 	ret := _m.Called(cToken)
@@ -169,40 +296,133 @@ func (_c *MockITokenManager_IsExpiredHelper_Call) RunAndReturn(run func(*client.
 	return _c
 }
 
-// SetClientIdAndSecret provides a mock function with given fields: clientID, clientSecret
-func (_m *MockITokenManager) SetClientIdAndSecret(clientID string, clientSecret string) {
-	_m.Called(clientID, clientSecret)
+// Ping provides a mock function with given fields: ctx
+func (_m *MockITokenManager) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
-// MockITokenManager_SetClientIdAndSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetClientIdAndSecret'
-type MockITokenManager_SetClientIdAndSecret_Call struct {
+// MockITokenManager_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type MockITokenManager_Ping_Call struct {
 	*mock.Call
 }
 
-// SetClientIdAndSecret is a helper method to define mock.On call
-//   - clientID string
-//   - clientSecret string
-func (_e *MockITokenManager_Expecter) SetClientIdAndSecret(clientID interface{}, clientSecret interface{}) *MockITokenManager_SetClientIdAndSecret_Call {
-	return &MockITokenManager_SetClientIdAndSecret_Call{Call: _e.mock.On("SetClientIdAndSecret", clientID, clientSecret)}
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockITokenManager_Expecter) Ping(ctx interface{}) *MockITokenManager_Ping_Call {
+	return &MockITokenManager_Ping_Call{Call: _e.mock.On("Ping", ctx)}
 }
 
-func (_c *MockITokenManager_SetClientIdAndSecret_Call) Run(run func(clientID string, clientSecret string)) *MockITokenManager_SetClientIdAndSecret_Call {
+func (_c *MockITokenManager_Ping_Call) Run(run func(ctx context.Context)) *MockITokenManager_Ping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockITokenManager_SetClientIdAndSecret_Call) Return() *MockITokenManager_SetClientIdAndSecret_Call {
+func (_c *MockITokenManager_Ping_Call) Return(_a0 error) *MockITokenManager_Ping_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITokenManager_Ping_Call) RunAndReturn(run func(context.Context) error) *MockITokenManager_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reconfigure provides a mock function with given fields: baseURL, realm, defaults
+func (_m *MockITokenManager) Reconfigure(baseURL string, realm string, defaults client.Credentials) {
+	_m.Called(baseURL, realm, defaults)
+}
+
+// MockITokenManager_Reconfigure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reconfigure'
+type MockITokenManager_Reconfigure_Call struct {
+	*mock.Call
+}
+
+// Reconfigure is a helper method to define mock.On call
+//   - baseURL string
+//   - realm string
+//   - defaults client.Credentials
+func (_e *MockITokenManager_Expecter) Reconfigure(baseURL interface{}, realm interface{}, defaults interface{}) *MockITokenManager_Reconfigure_Call {
+	return &MockITokenManager_Reconfigure_Call{Call: _e.mock.On("Reconfigure", baseURL, realm, defaults)}
+}
+
+func (_c *MockITokenManager_Reconfigure_Call) Run(run func(baseURL string, realm string, defaults client.Credentials)) *MockITokenManager_Reconfigure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(client.Credentials))
+	})
+	return _c
+}
+
+func (_c *MockITokenManager_Reconfigure_Call) Return() *MockITokenManager_Reconfigure_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockITokenManager_SetClientIdAndSecret_Call) RunAndReturn(run func(string, string)) *MockITokenManager_SetClientIdAndSecret_Call {
+func (_c *MockITokenManager_Reconfigure_Call) RunAndReturn(run func(string, string, client.Credentials)) *MockITokenManager_Reconfigure_Call {
 	_c.Run(run)
 	return _c
 }
 
+// Start provides a mock function with given fields: ctx
+func (_m *MockITokenManager) Start(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockITokenManager_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockITokenManager_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockITokenManager_Expecter) Start(ctx interface{}) *MockITokenManager_Start_Call {
+	return &MockITokenManager_Start_Call{Call: _e.mock.On("Start", ctx)}
+}
+
+func (_c *MockITokenManager_Start_Call) Run(run func(ctx context.Context)) *MockITokenManager_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockITokenManager_Start_Call) Return(_a0 error) *MockITokenManager_Start_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITokenManager_Start_Call) RunAndReturn(run func(context.Context) error) *MockITokenManager_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockITokenManager creates a new instance of MockITokenManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockITokenManager(t interface {
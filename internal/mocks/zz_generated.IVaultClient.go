@@ -20,6 +20,51 @@ func (_m *MockIVaultClient) EXPECT() *MockIVaultClient_Expecter {
 	return &MockIVaultClient_Expecter{mock: &_m.Mock}
 }
 
+// Address provides a mock function with no fields
+func (_m *MockIVaultClient) Address() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Address")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockIVaultClient_Address_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Address'
+type MockIVaultClient_Address_Call struct {
+	*mock.Call
+}
+
+// Address is a helper method to define mock.On call
+func (_e *MockIVaultClient_Expecter) Address() *MockIVaultClient_Address_Call {
+	return &MockIVaultClient_Address_Call{Call: _e.mock.On("Address")}
+}
+
+func (_c *MockIVaultClient_Address_Call) Run(run func()) *MockIVaultClient_Address_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockIVaultClient_Address_Call) Return(_a0 string) *MockIVaultClient_Address_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIVaultClient_Address_Call) RunAndReturn(run func() string) *MockIVaultClient_Address_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Auth provides a mock function with no fields
 func (_m *MockIVaultClient) Auth() client.AuthAPI {
 	ret := _m.Called()
@@ -67,6 +112,54 @@ func (_c *MockIVaultClient_Auth_Call) RunAndReturn(run func() client.AuthAPI) *M
 	return _c
 }
 
+// KVv1 provides a mock function with given fields: mount
+func (_m *MockIVaultClient) KVv1(mount string) client.KvAPI {
+	ret := _m.Called(mount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KVv1")
+	}
+
+	var r0 client.KvAPI
+	if rf, ok := ret.Get(0).(func(string) client.KvAPI); ok {
+		r0 = rf(mount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(client.KvAPI)
+		}
+	}
+
+	return r0
+}
+
+// MockIVaultClient_KVv1_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'KVv1'
+type MockIVaultClient_KVv1_Call struct {
+	*mock.Call
+}
+
+// KVv1 is a helper method to define mock.On call
+//   - mount string
+func (_e *MockIVaultClient_Expecter) KVv1(mount interface{}) *MockIVaultClient_KVv1_Call {
+	return &MockIVaultClient_KVv1_Call{Call: _e.mock.On("KVv1", mount)}
+}
+
+func (_c *MockIVaultClient_KVv1_Call) Run(run func(mount string)) *MockIVaultClient_KVv1_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockIVaultClient_KVv1_Call) Return(_a0 client.KvAPI) *MockIVaultClient_KVv1_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIVaultClient_KVv1_Call) RunAndReturn(run func(string) client.KvAPI) *MockIVaultClient_KVv1_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // KVv2 provides a mock function with given fields: mount
 func (_m *MockIVaultClient) KVv2(mount string) client.KvAPI {
 	ret := _m.Called(mount)
@@ -162,6 +255,52 @@ func (_c *MockIVaultClient_Logical_Call) RunAndReturn(run func() client.LogicalA
 	return _c
 }
 
+// SetAddress provides a mock function with given fields: address
+func (_m *MockIVaultClient) SetAddress(address string) error {
+	ret := _m.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAddress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIVaultClient_SetAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAddress'
+type MockIVaultClient_SetAddress_Call struct {
+	*mock.Call
+}
+
+// SetAddress is a helper method to define mock.On call
+//   - address string
+func (_e *MockIVaultClient_Expecter) SetAddress(address interface{}) *MockIVaultClient_SetAddress_Call {
+	return &MockIVaultClient_SetAddress_Call{Call: _e.mock.On("SetAddress", address)}
+}
+
+func (_c *MockIVaultClient_SetAddress_Call) Run(run func(address string)) *MockIVaultClient_SetAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockIVaultClient_SetAddress_Call) Return(_a0 error) *MockIVaultClient_SetAddress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIVaultClient_SetAddress_Call) RunAndReturn(run func(string) error) *MockIVaultClient_SetAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetNamespace provides a mock function with given fields: namespace
 func (_m *MockIVaultClient) SetNamespace(namespace string) {
 	_m.Called(namespace)
@@ -0,0 +1,97 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	client "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	api "github.com/hashicorp/vault/api"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockVaultAuth is an autogenerated mock type for the VaultAuth type
+type MockVaultAuth struct {
+	mock.Mock
+}
+
+type MockVaultAuth_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockVaultAuth) EXPECT() *MockVaultAuth_Expecter {
+	return &MockVaultAuth_Expecter{mock: &_m.Mock}
+}
+
+// Login provides a mock function with given fields: ctx, cli
+func (_m *MockVaultAuth) Login(ctx context.Context, cli client.IVaultClient) (*api.SecretAuth, error) {
+	ret := _m.Called(ctx, cli)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *api.SecretAuth
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, client.IVaultClient) (*api.SecretAuth, error)); ok {
+		return rf(ctx, cli)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, client.IVaultClient) *api.SecretAuth); ok {
+		r0 = rf(ctx, cli)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.SecretAuth)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, client.IVaultClient) error); ok {
+		r1 = rf(ctx, cli)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockVaultAuth_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type MockVaultAuth_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cli client.IVaultClient
+func (_e *MockVaultAuth_Expecter) Login(ctx interface{}, cli interface{}) *MockVaultAuth_Login_Call {
+	return &MockVaultAuth_Login_Call{Call: _e.mock.On("Login", ctx, cli)}
+}
+
+func (_c *MockVaultAuth_Login_Call) Run(run func(ctx context.Context, cli client.IVaultClient)) *MockVaultAuth_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(client.IVaultClient))
+	})
+	return _c
+}
+
+func (_c *MockVaultAuth_Login_Call) Return(_a0 *api.SecretAuth, _a1 error) *MockVaultAuth_Login_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockVaultAuth_Login_Call) RunAndReturn(run func(context.Context, client.IVaultClient) (*api.SecretAuth, error)) *MockVaultAuth_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockVaultAuth creates a new instance of MockVaultAuth. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockVaultAuth(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockVaultAuth {
+	mock := &MockVaultAuth{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,66 @@
+package reconciler
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+// Metadata fields of the API requests that come from the spec
+var specMetadataFields = []string{"tags", "location"}
+
+// lastErrorStatus converts an API error into the status.lastError block
+func lastErrorStatus(apiErr *arubaClient.ApiError) *v1alpha1.APIErrorStatus {
+	lastError := &v1alpha1.APIErrorStatus{
+		Type:    apiErr.Type,
+		Title:   apiErr.Title,
+		Status:  int32(apiErr.Status),
+		TraceID: apiErr.TraceId,
+	}
+	for _, detail := range apiErr.Errors {
+		lastError.FieldErrors = append(lastError.FieldErrors, v1alpha1.FieldError{
+			Field:   SpecPath(detail.Field),
+			Message: detail.Message,
+		})
+	}
+	return lastError
+}
+
+// SpecPath translates the path of a field of an API request into the path of the
+// spec field it is built from, e.g. properties.sizeGb becomes spec.sizeGb and
+// metadata.location.value becomes spec.location.value. Unknown paths are returned
+// in the same camel case notation, the API reports them as Properties.SizeGb too.
+func SpecPath(field string) string {
+	field = strings.TrimPrefix(strings.TrimPrefix(field, "$"), ".")
+	if field == "" {
+		return field
+	}
+
+	segments := strings.Split(field, ".")
+	for i, segment := range segments {
+		segments[i] = lowerFirst(segment)
+	}
+
+	switch {
+	case segments[0] == "properties" && len(segments) > 1:
+		segments[0] = "spec"
+	case segments[0] == "metadata" && len(segments) > 1 && isSpecMetadataField(segments[1]):
+		segments = append([]string{"spec"}, segments[1:]...)
+	}
+	return strings.Join(segments, ".")
+}
+
+// isSpecMetadataField reports whether a request metadata field is set from the spec, ignoring any index
+func isSpecMetadataField(segment string) bool {
+	name, _, _ := strings.Cut(segment, "[")
+	return slices.Contains(specMetadataFields, name)
+}
+
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
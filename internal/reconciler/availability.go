@@ -0,0 +1,103 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
+)
+
+// HandleAPIUnavailable pauses the reconciliation while the circuit breaker of the API gateway of
+// the request, the one of the tenant or the configured one, is open.
+// The APIAvailable condition is set once per outage and cleared by the first reconcile after it.
+// It returns true when the reconcile must stop with the returned result.
+func (r *Reconciler) HandleAPIUnavailable(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (bool, ctrl.Result, error) {
+	if r.HelperClient == nil {
+		return false, ctrl.Result{}, nil
+	}
+	phaseLogger := ctrl.Log.WithValues("Phase", status.Phase, "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Name", obj.GetName())
+
+	retryAfter := r.HelperClient.Breaker(ctx).RetryAfter()
+	if retryAfter <= 0 {
+		if !meta.IsStatusConditionFalse(status.Conditions, v1alpha1.ConditionTypeAPIAvailable) {
+			return false, ctrl.Result{}, nil
+		}
+		status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeAPIAvailable, metav1.ConditionTrue, "APIReachable", "Aruba API gateway is reachable")
+		if err := r.Client.Status().Update(ctx, obj); err != nil {
+			phaseLogger.Error(err, "failed to update status")
+			return true, ctrl.Result{}, err
+		}
+		return false, ctrl.Result{}, nil
+	}
+
+	if !meta.IsStatusConditionFalse(status.Conditions, v1alpha1.ConditionTypeAPIAvailable) {
+		message := "Aruba API gateway is unavailable, reconciliation is paused"
+		status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeAPIAvailable, metav1.ConditionFalse, "CircuitOpen", message)
+		if err := r.Client.Status().Update(ctx, obj); err != nil {
+			phaseLogger.Error(err, "failed to update status")
+			return true, ctrl.Result{}, err
+		}
+	}
+
+	phaseLogger.Info("API gateway unavailable, pausing reconciliation", "retryAfter", retryAfter)
+	return true, ctrl.Result{RequeueAfter: retryAfter}, nil
+}
+
+// requeueOnCircuitOpen returns the result of a reconcile whose API call was refused by the open
+// circuit breaker: the resource is left untouched and retried once the API is probed again
+func requeueOnCircuitOpen(err error) (ctrl.Result, bool) {
+	var circuitErr *arubaClient.CircuitOpenError
+	if !errors.As(err, &circuitErr) {
+		return ctrl.Result{}, false
+	}
+	return ctrl.Result{RequeueAfter: max(circuitErr.RetryAfter, time.Second)}, true
+}
+
+// phaseElapsed returns the time spent in the current phase, not counting the outages of the
+// API gateway of the request
+func (r *Reconciler) phaseElapsed(ctx context.Context, status *v1alpha1.ResourceStatus) time.Duration {
+	elapsed := time.Since(status.PhaseStartTime.Time)
+	if r.HelperClient != nil {
+		elapsed -= r.HelperClient.Breaker(ctx).UnavailableSince(status.PhaseStartTime.Time)
+	}
+	return elapsed
+}
+
+// APIGatewayCheck is a readiness check confirming that the configured API gateway is reachable.
+// It fails without calling the gateway while its circuit breaker is open. The gateways of the
+// tenants are not checked: their outages pause only the resources of those tenants.
+func (r *Reconciler) APIGatewayCheck(ctx context.Context) error {
+	if r.HelperClient == nil {
+		return nil
+	}
+	if !r.HelperClient.Breaker(ctx).Available() {
+		return fmt.Errorf("aruba API gateway unavailable, circuit breaker open")
+	}
+	return r.HelperClient.Ping(ctx)
+}
+
+// KeycloakCheck is a readiness check confirming that Keycloak is reachable. It does not log in,
+// so that the credentials rejected for one tenant do not make the whole operator unready.
+func (r *Reconciler) KeycloakCheck(ctx context.Context) error {
+	if r.TokenManager == nil {
+		return nil
+	}
+	return r.TokenManager.Ping(ctx)
+}
+
+// VaultCheck is a readiness check confirming that the Vault token is valid and not expired
+func (r *Reconciler) VaultCheck(ctx context.Context) error {
+	if r.VaultAuthClient == nil {
+		return fmt.Errorf("vault client not initialized")
+	}
+	return r.VaultAuthClient.CheckToken(ctx)
+}
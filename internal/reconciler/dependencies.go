@@ -0,0 +1,156 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiError "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
+)
+
+// dependencyRecheckInterval is a safety net while waiting: dependents are normally
+// enqueued by the watches on referenced resources as soon as they change
+const dependencyRecheckInterval = time.Minute
+
+// Reasons why a dependency is not resolved
+const (
+	DependencyNotFound       = "NotFound"
+	DependencyNotProvisioned = "NotProvisioned"
+	DependencyFailed         = "Failed"
+)
+
+// Dependency is a reference from a resource to another resource it needs
+type Dependency struct {
+	// Kind is the kind of the referenced resource
+	Kind      string
+	Reference v1alpha1.ResourceReference
+}
+
+// UnresolvedDependency is a dependency that cannot be used yet and why
+type UnresolvedDependency struct {
+	Dependency
+	Namespace string
+	Reason    string
+}
+
+func (d UnresolvedDependency) String() string {
+	return fmt.Sprintf("%s %s/%s (%s)", d.Kind, d.Namespace, d.Reference.Name, d.Reason)
+}
+
+// DependencyResolver is implemented by reconcilers of resources that reference other resources
+type DependencyResolver interface {
+	Dependencies(obj client.Object) []Dependency
+}
+
+// HandleDependencies checks the dependencies of a resource about to be created or updated.
+// While any of them is unresolved the resource is kept in the Waiting phase, which is not
+// subject to the phase timeout, and the DependenciesReady condition lists what is missing.
+// It returns true when the reconcile must stop with the returned result.
+func (r *Reconciler) HandleDependencies(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, dependencies []Dependency) (bool, ctrl.Result, error) {
+	phaseLogger := ctrl.Log.WithValues("Phase", status.Phase, "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Name", obj.GetName())
+
+	unresolved, err := r.unresolvedDependencies(ctx, obj, dependencies)
+	if err != nil {
+		phaseLogger.Error(err, "failed to check dependencies")
+		return true, ctrl.Result{}, err
+	}
+
+	if len(unresolved) == 0 {
+		message := "All referenced resources are provisioned"
+		status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeDependenciesReady, metav1.ConditionTrue, "DependenciesResolved", message)
+		if status.Phase != v1alpha1.ResourcePhaseWaiting {
+			// The condition is persisted by the phase handler
+			return false, ctrl.Result{}, nil
+		}
+
+		nextPhase := v1alpha1.ResourcePhaseCreating
+		if status.ResourceID != "" {
+			nextPhase = v1alpha1.ResourcePhaseUpdating
+		}
+		result, err := r.Next(ctx, obj, status, nextPhase, metav1.ConditionFalse, "DependenciesResolved", message, true)
+		return true, result, err
+	}
+
+	descriptions := make([]string, 0, len(unresolved))
+	for _, dependency := range unresolved {
+		descriptions = append(descriptions, dependency.String())
+	}
+	message := "Waiting for dependencies: " + strings.Join(descriptions, ", ")
+	status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeDependenciesReady, metav1.ConditionFalse, "DependenciesNotReady", message)
+
+	if status.Phase != v1alpha1.ResourcePhaseWaiting {
+		result, err := r.Next(ctx, obj, status, v1alpha1.ResourcePhaseWaiting, metav1.ConditionFalse, "WaitingForDependencies", message, true)
+		return true, result, err
+	}
+
+	if status.Message != message {
+		status.Message = message
+		if err := r.Client.Status().Update(ctx, obj); err != nil {
+			phaseLogger.Error(err, "failed to update status")
+			return true, ctrl.Result{}, err
+		}
+	}
+
+	phaseLogger.Info(message)
+	return true, ctrl.Result{RequeueAfter: dependencyRecheckInterval}, nil
+}
+
+// unresolvedDependencies returns the dependencies whose referenced resource is missing, not yet provisioned or failed
+func (r *Reconciler) unresolvedDependencies(ctx context.Context, obj client.Object, dependencies []Dependency) ([]UnresolvedDependency, error) {
+	var unresolved []UnresolvedDependency
+	for _, dependency := range dependencies {
+		namespace := dependency.Reference.Namespace
+		if namespace == "" {
+			namespace = obj.GetNamespace()
+		}
+
+		runtimeObj, err := r.Scheme.New(v1alpha1.GroupVersion.WithKind(dependency.Kind))
+		if err != nil {
+			return nil, fmt.Errorf("unknown dependency kind %s: %w", dependency.Kind, err)
+		}
+		referenced, ok := runtimeObj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("dependency kind %s is not a client object", dependency.Kind)
+		}
+
+		reason := ""
+		err = r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: dependency.Reference.Name}, referenced)
+		switch {
+		case apiError.IsNotFound(err):
+			reason = DependencyNotFound
+		case err != nil:
+			return nil, fmt.Errorf("failed to get referenced %s %s/%s: %w", dependency.Kind, namespace, dependency.Reference.Name, err)
+		default:
+			phase, resourceID := phaseAndResourceID(referenced)
+			if v1alpha1.ResourcePhase(phase) == v1alpha1.ResourcePhaseFailed {
+				reason = DependencyFailed
+			} else if resourceID == "" {
+				reason = DependencyNotProvisioned
+			}
+		}
+
+		if reason != "" {
+			unresolved = append(unresolved, UnresolvedDependency{
+				Dependency: dependency,
+				Namespace:  namespace,
+				Reason:     reason,
+			})
+		}
+	}
+	return unresolved, nil
+}
+
+// waitsForDependencies reports whether dependencies must be resolved before handling the phase
+func waitsForDependencies(phase v1alpha1.ResourcePhase) bool {
+	return phase == v1alpha1.ResourcePhaseCreating ||
+		phase == v1alpha1.ResourcePhaseUpdating ||
+		phase == v1alpha1.ResourcePhaseWaiting
+}
@@ -0,0 +1,44 @@
+package reconciler
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reasons of the transitions reported as Warning events
+var warningReasons = []string{"ClientError", "ServerError", "ReconciliationTimeout", "ProvisioningFailed"}
+
+// Reasons of the transitions to Created reported as Normal events
+var normalReasons = []string{"Created", "Updated", "Imported"}
+
+// recordTransition emits the event matching a phase transition done by Next.
+// Retries in the same phase with the same reason are reported once: the first event
+// carries the details, the following attempts only update the status message.
+func (r *Reconciler) recordTransition(obj client.Object, currentPhase, nextPhase v1alpha1.ResourcePhase, previousReason, reason, message string) {
+	switch {
+	case slices.Contains(warningReasons, reason):
+		if currentPhase == nextPhase && previousReason == reason {
+			return
+		}
+		r.recordEvent(obj, corev1.EventTypeWarning, reason, message)
+	case slices.Contains(normalReasons, reason):
+		if nextPhase != v1alpha1.ResourcePhaseCreated || currentPhase == nextPhase {
+			return
+		}
+		r.recordEvent(obj, corev1.EventTypeNormal, reason, message)
+	}
+}
+
+// recordEvent emits an event on obj, when the reconciler has a recorder
+func (r *Reconciler) recordEvent(obj client.Object, eventType, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(obj, eventType, reason, message)
+}
@@ -0,0 +1,62 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+// Importer is implemented by reconcilers of resources that can adopt an existing remote resource
+type Importer interface {
+	// Import verifies that the remote resource exists and fills the kind specific status fields
+	Import(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, resourceID string) error
+}
+
+// HandleImport adopts the remote resource named by the import annotation instead of creating one.
+// Once verified the resource goes straight to Created, where drift detection reports any
+// difference between the spec and the imported resource.
+func (r *Reconciler) HandleImport(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, resourceReconciler ResourceReconciler, resourceID string) (ctrl.Result, error) {
+	phaseLogger := ctrl.Log.WithValues("Phase", status.Phase, "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Name", obj.GetName())
+
+	importer, ok := resourceReconciler.(Importer)
+	if !ok {
+		return r.Next(ctx, obj, status, v1alpha1.ResourcePhaseFailed, metav1.ConditionFalse, "ImportNotSupported",
+			fmt.Sprintf("Importing %s resources is not supported", obj.GetObjectKind().GroupVersionKind().Kind), false)
+	}
+
+	err := importer.Import(ctx, obj, status, resourceID)
+	if err != nil {
+		var apiErr *arubaClient.ApiError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return r.Next(ctx, obj, status, v1alpha1.ResourcePhaseFailed, metav1.ConditionFalse, "ImportNotFound",
+				fmt.Sprintf("Remote resource %s to import was not found", resourceID), false)
+		}
+		return r.NextToFailedOnApiError(ctx, obj, status, err)
+	}
+
+	status.ResourceID = resourceID
+	phaseLogger.Info("imported remote resource", "resourceID", resourceID)
+
+	return r.Next(
+		ctx,
+		obj,
+		status,
+		v1alpha1.ResourcePhaseCreated,
+		metav1.ConditionTrue,
+		"Imported",
+		fmt.Sprintf("Resource imported from remote resource %s", resourceID),
+		true,
+	)
+}
+
+// importID returns the ID of the remote resource to adopt, if any
+func importID(obj client.Object) string {
+	return obj.GetAnnotations()[v1alpha1.AnnotationImportID]
+}
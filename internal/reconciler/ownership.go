@@ -0,0 +1,48 @@
+package reconciler
+
+import (
+	"slices"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ownershipTagPrefix marks the remote tag carrying the UID of the object that created the resource.
+// It lets the Creating phase find a resource whose create response was lost, instead of creating a duplicate.
+const ownershipTagPrefix = "k8s-uid-"
+
+// OwnershipTag returns the remote tag identifying resources created for obj
+func OwnershipTag(obj client.Object) string {
+	return ownershipTagPrefix + string(obj.GetUID())
+}
+
+// OwnedTags returns the spec tags plus the ownership tag of obj, to be sent on create and update
+func OwnedTags(obj client.Object, tags []string) []string {
+	owned := make([]string, 0, len(tags)+1)
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, ownershipTagPrefix) {
+			owned = append(owned, tag)
+		}
+	}
+	return append(owned, OwnershipTag(obj))
+}
+
+// WithoutOwnershipTag returns the remote tags without the ownership tag, to be compared with the spec
+func WithoutOwnershipTag(tags []string) []string {
+	return slices.DeleteFunc(slices.Clone(tags), func(tag string) bool {
+		return strings.HasPrefix(tag, ownershipTagPrefix)
+	})
+}
+
+// FindOwned returns the remote resource carrying the ownership tag of obj, or nil if there is none
+func FindOwned[T any](obj client.Object, items []T, tagsFunc func(*T) []string) *T {
+	tag := OwnershipTag(obj)
+	for i := range items {
+		if slices.Contains(tagsFunc(&items[i]), tag) {
+			ctrl.Log.Info("adopting remote resource created by a previous attempt", "Name", obj.GetName(), "Namespace", obj.GetNamespace())
+			return &items[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+)
+
+const (
+	// ProviderConfigSecretIndex indexes the ProviderConfigs by their credentials Secret
+	ProviderConfigSecretIndex = "spec.credentialsSecretRef"
+
+	defaultClientIDKey     = "client-id"
+	defaultClientSecretKey = "client-secret"
+)
+
+// ProviderConfigFor returns the ProviderConfig of the tenant, nil when the tenant has none
+func (r *Reconciler) ProviderConfigFor(ctx context.Context, tenant string) (*v1alpha1.ProviderConfig, error) {
+	if tenant == "" {
+		return nil, nil
+	}
+
+	providerConfigs := &v1alpha1.ProviderConfigList{}
+	if err := r.List(ctx, providerConfigs); err != nil {
+		return nil, fmt.Errorf("failed to list provider configs: %w", err)
+	}
+
+	var found *v1alpha1.ProviderConfig
+	for i := range providerConfigs.Items {
+		if providerConfigs.Items[i].Spec.Tenant != tenant {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("tenant %s is configured by both ProviderConfigs %s and %s", tenant, found.Name, providerConfigs.Items[i].Name)
+		}
+		found = &providerConfigs.Items[i]
+	}
+	return found, nil
+}
+
+// ProviderConfigCredentials reads the credentials referenced by the ProviderConfig from its Secret
+func (r *Reconciler) ProviderConfigCredentials(ctx context.Context, providerConfig *v1alpha1.ProviderConfig) (arubaClient.Credentials, *corev1.Secret, error) {
+	ref := providerConfig.Spec.CredentialsSecretRef
+	secret := &corev1.Secret{}
+	if err := r.secretReader().Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return arubaClient.Credentials{}, nil, fmt.Errorf("failed to get credentials secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	clientIDKey, clientSecretKey := ref.ClientIDKey, ref.ClientSecretKey
+	if clientIDKey == "" {
+		clientIDKey = defaultClientIDKey
+	}
+	if clientSecretKey == "" {
+		clientSecretKey = defaultClientSecretKey
+	}
+	clientID, clientSecret := string(secret.Data[clientIDKey]), string(secret.Data[clientSecretKey])
+	if clientID == "" || clientSecret == "" {
+		return arubaClient.Credentials{}, secret, fmt.Errorf("credentials secret %s/%s must set %s and %s", ref.Namespace, ref.Name, clientIDKey, clientSecretKey)
+	}
+
+	return arubaClient.Credentials{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		KeycloakURL:  providerConfig.Spec.KeycloakURL,
+	}, secret, nil
+}
+
+// providerConfigCredentials returns the credentials lookup of the tenants configured by a ProviderConfig
+func (r *Reconciler) providerConfigCredentials(providerConfig *v1alpha1.ProviderConfig) arubaClient.CredentialsFunc {
+	return func(ctx context.Context, _ string) (arubaClient.Credentials, error) {
+		credentials, _, err := r.ProviderConfigCredentials(ctx, providerConfig)
+		return credentials, err
+	}
+}
+
+// secretReader returns the reader of the credentials Secrets. Secrets are read from the API server
+// rather than the cache, so the operator does not keep every Secret of the cluster in memory.
+func (r *Reconciler) secretReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
@@ -5,21 +5,27 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"os"
 	"slices"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	apiError "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
 	arubaClient "github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/metrics"
 	"github.com/Arubacloud/arubacloud-resource-operator/internal/util"
 )
 
@@ -27,6 +33,12 @@ const (
 	requeueAfter = 20 * time.Second
 	// maxPhaseTimeout defines the maximum time a resource can remain in a non-final phase
 	maxPhaseTimeout = 5 * time.Minute
+	// maxDeletionTimeout defines the maximum time to wait for a remote resource to be deleted
+	maxDeletionTimeout = 15 * time.Minute
+	// defaultDriftCheckInterval is used when no drift check interval is configured
+	defaultDriftCheckInterval = 10 * time.Minute
+	// maxRetryBackoff caps the exponential backoff between automatic retries of failed resources
+	maxRetryBackoff = 30 * time.Minute
 )
 
 // ResourceReconciler is an interface that must be implemented by all resource reconcilers
@@ -44,9 +56,17 @@ type Reconciler struct {
 	client.Client
 	*runtime.Scheme
 	*arubaClient.HelperClient
-	*arubaClient.AppRoleClient
+	*arubaClient.VaultAuthClient
 	TokenManager   arubaClient.ITokenManager
 	VaultIsEnabled bool
+	// APIReader reads objects bypassing the cache, e.g. the credentials Secrets of the ProviderConfigs
+	APIReader client.Reader
+	// Recorder emits the events of phase transitions and API errors
+	Recorder record.EventRecorder
+	// MaxConcurrentReconciles is the number of workers each controller runs
+	MaxConcurrentReconciles int
+	// DriftCheckInterval is how often resources in the Created phase are compared with the remote system
+	DriftCheckInterval time.Duration
 }
 
 // ReconcilerConfig holds configuration for setting up Reconciler
@@ -63,40 +83,125 @@ type ReconcilerConfig struct {
 	RoleID         string
 	RoleSecret     string
 	KVMount        string
+	KVVersion      int
+	KVPathTemplate string
 	HTTPClient     *http.Client
+
+	// VaultAuthMethod selects the Vault auth method, see VaultSettings
+	VaultAuthMethod     string
+	KubernetesAuthPath  string
+	KubernetesAuthRole  string
+	KubernetesTokenFile string
+	VaultTokenFile      string
+	// VaultSecretCacheTTL is how long the tenant credentials read from Vault are cached
+	VaultSecretCacheTTL time.Duration
+
+	MaxConcurrentReconciles int
+	DriftCheckInterval      time.Duration
+	// RetryPolicy, RateLimit and CircuitBreaker apply to the requests sent to the API gateway
+	RetryPolicy    arubaClient.RetryPolicy
+	RateLimit      arubaClient.RateLimit
+	CircuitBreaker arubaClient.CircuitBreakerSettings
+	// LogAPIBodies logs the bodies of the API requests and responses
+	LogAPIBodies bool
+}
+
+// VaultSettings returns the settings of the Vault client, with the auth method selected by VaultAuthMethod
+func (cfg ReconcilerConfig) VaultSettings() arubaClient.VaultSettings {
+	var auth arubaClient.VaultAuth
+	switch cfg.VaultAuthMethod {
+	case arubaClient.VaultAuthKubernetes:
+		auth = arubaClient.KubernetesAuth{Path: cfg.KubernetesAuthPath, Role: cfg.KubernetesAuthRole, TokenFile: cfg.KubernetesTokenFile}
+	case arubaClient.VaultAuthTokenFile:
+		auth = arubaClient.TokenFileAuth{File: cfg.VaultTokenFile}
+	default:
+		auth = arubaClient.AppRoleAuth{Path: cfg.RolePath, RoleID: cfg.RoleID, SecretID: cfg.RoleSecret}
+	}
+	return arubaClient.VaultSettings{
+		Address:        cfg.VaultAddress,
+		Namespace:      cfg.Namespace,
+		Auth:           auth,
+		KVMount:        cfg.KVMount,
+		KVVersion:      cfg.KVVersion,
+		PathTemplate:   cfg.KVPathTemplate,
+		SecretCacheTTL: cfg.VaultSecretCacheTTL,
+	}
 }
 
-// NewReconciler creates a new base reconciler
-func NewReconciler(mgr ctrl.Manager, cfg ReconcilerConfig) *Reconciler {
-	var vaultAuth *arubaClient.AppRoleClient
+// NewReconciler creates a new base reconciler. When Vault is enabled the Vault client
+// is added to the manager, which logs in and renews its token while running. So is the
+// token manager, which refreshes the Keycloak tokens before they expire.
+func NewReconciler(mgr ctrl.Manager, cfg ReconcilerConfig) (*Reconciler, error) {
+	var vaultAuth *arubaClient.VaultAuthClient
 	helperClientInstance := arubaClient.NewHelperClient(mgr.GetClient(), cfg.HTTPClient, cfg.APIGateway)
+	helperClientInstance.RetryPolicy = cfg.RetryPolicy
+	helperClientInstance.RateLimiter = arubaClient.NewTenantRateLimiter(cfg.RateLimit)
+	helperClientInstance.Breakers = arubaClient.NewCircuitBreakers(cfg.CircuitBreaker)
+	helperClientInstance.LogBodies = cfg.LogAPIBodies
 
 	if cfg.VaultIsEnabled {
-		vaultClient := arubaClient.VaultClient(cfg.VaultAddress)
-		var err error
-		vaultAuth, err = arubaClient.NewAppRoleClient(cfg.Namespace, cfg.RolePath, cfg.RoleID, cfg.RoleSecret, cfg.KVMount, vaultClient)
+		vaultClient, err := arubaClient.VaultClient(cfg.VaultAddress)
 		if err != nil {
-			ctrl.Log.Error(err, "failed to init vault client: %v")
-			os.Exit(1)
+			return nil, err
+		}
+		vaultAuth = arubaClient.NewVaultAuthClient(cfg.VaultSettings(), vaultClient)
+		if err := mgr.Add(vaultAuth); err != nil {
+			return nil, fmt.Errorf("failed to add vault client to manager: %w", err)
 		}
-		defer vaultAuth.Close()
 		ctrl.Log.V(1).Info("Vault integration is enabled; Vault client initialized")
 	}
 
-	oauthClient := arubaClient.NewTokenManager(cfg.KeycloakURL, cfg.RealmAPI, "", "", nil)
-
 	if !cfg.VaultIsEnabled {
 		ctrl.Log.V(1).Info("Vault integration is disabled; using static Keycloak client credentials")
-		oauthClient.SetClientIdAndSecret(cfg.ClientID, cfg.ClientSecret)
+	}
+	oauthClient := arubaClient.NewTokenManager(cfg.KeycloakURL, cfg.RealmAPI, cfg.ClientID, cfg.ClientSecret, nil)
+	if err := mgr.Add(oauthClient); err != nil {
+		return nil, fmt.Errorf("failed to add token manager to manager: %w", err)
+	}
+	// A token rejected by the API gateway is replaced by a new login at the next reconcile
+	helperClientInstance.OnUnauthorized = func(ctx context.Context) {
+		oauthClient.ExpireToken(arubaClient.TenantFromContext(ctx))
 	}
 
 	return &Reconciler{
-		Client:         mgr.GetClient(),
-		Scheme:         mgr.GetScheme(),
-		HelperClient:   helperClientInstance,
-		AppRoleClient:  vaultAuth,
-		TokenManager:   oauthClient,
-		VaultIsEnabled: cfg.VaultIsEnabled,
+		Client:          mgr.GetClient(),
+		APIReader:       mgr.GetAPIReader(),
+		Scheme:          mgr.GetScheme(),
+		HelperClient:    helperClientInstance,
+		VaultAuthClient: vaultAuth,
+		TokenManager:    oauthClient,
+		VaultIsEnabled:  cfg.VaultIsEnabled,
+		Recorder:        mgr.GetEventRecorderFor("arubacloud-resource-operator"),
+
+		MaxConcurrentReconciles: cfg.MaxConcurrentReconciles,
+		DriftCheckInterval:      cfg.DriftCheckInterval,
+	}, nil
+}
+
+// Reconfigure applies the endpoints and credentials of cfg to the running clients: the API
+// gateway of the HelperClient, the Keycloak settings of the TokenManager and the Vault login
+// settings. Vault is reconfigured first, as it is the only step that can fail: on error nothing
+// is changed. Enabling or disabling Vault takes effect after a restart, as its client is only
+// started by the manager when enabled at startup.
+func (r *Reconciler) Reconfigure(cfg ReconcilerConfig) error {
+	if r.VaultAuthClient != nil && cfg.VaultIsEnabled {
+		if err := r.VaultAuthClient.Reconfigure(cfg.VaultSettings()); err != nil {
+			return fmt.Errorf("failed to reconfigure vault: %w", err)
+		}
+	}
+
+	r.SetAPIGateway(cfg.APIGateway)
+	r.TokenManager.Reconfigure(cfg.KeycloakURL, cfg.RealmAPI, arubaClient.Credentials{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+	})
+	return nil
+}
+
+// ControllerOptions returns the options shared by all resource controllers
+func (r *Reconciler) ControllerOptions() controller.Options {
+	return controller.Options{
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
 	}
 }
 
@@ -128,11 +233,18 @@ func (r *Reconciler) Reconcile(
 	}
 
 	ctrl.Log.V(1).Info("Setting tenant in Aruba client", "TenantID", tenant)
-	if err := r.Authenticate(ctx, *tenant); err != nil {
+	ctx, err = r.Authenticate(ctx, req.Namespace, *tenant)
+	if err != nil {
 		ctrl.Log.Error(err, "Failed to authenticate Aruba client", "tenantID", tenant)
 		return ctrl.Result{}, err
 	}
 
+	// Checked once authenticated, as the API gateway may be the one of the tenant
+	isPaused, pausedResult, pausedError := r.HandleAPIUnavailable(ctx, obj, status)
+	if isPaused {
+		return pausedResult, pausedError
+	}
+
 	isPhaseTimeout, phaseTimeoutResult, phaseTimeoutError := r.HandlePhaseTimeout(ctx, obj, status)
 	if isPhaseTimeout {
 		return phaseTimeoutResult, phaseTimeoutError
@@ -143,6 +255,18 @@ func (r *Reconciler) Reconcile(
 		return handleDeletionResult, handleDeletionError
 	}
 
+	resolver, hasDependencies := resourceReconciler.(DependencyResolver)
+	if (hasDependencies && waitsForDependencies(status.Phase)) || status.Phase == v1alpha1.ResourcePhaseWaiting {
+		var dependencies []Dependency
+		if hasDependencies {
+			dependencies = resolver.Dependencies(obj)
+		}
+		isWaiting, dependenciesResult, dependenciesError := r.HandleDependencies(ctx, obj, status, dependencies)
+		if isWaiting {
+			return dependenciesResult, dependenciesError
+		}
+	}
+
 	var reconcileResult ctrl.Result
 	var reconcileError error
 
@@ -150,7 +274,11 @@ func (r *Reconciler) Reconcile(
 	case "":
 		reconcileResult, reconcileError = resourceReconciler.Init(ctx, obj, status)
 	case v1alpha1.ResourcePhaseCreating:
-		reconcileResult, reconcileError = resourceReconciler.Creating(ctx, obj, status)
+		if resourceID := importID(obj); resourceID != "" && status.ResourceID == "" {
+			reconcileResult, reconcileError = r.HandleImport(ctx, obj, status, resourceReconciler, resourceID)
+		} else {
+			reconcileResult, reconcileError = resourceReconciler.Creating(ctx, obj, status)
+		}
 	case v1alpha1.ResourcePhaseProvisioning:
 		reconcileResult, reconcileError = resourceReconciler.Provisioning(ctx, obj, status)
 	case v1alpha1.ResourcePhaseUpdating:
@@ -163,8 +291,7 @@ func (r *Reconciler) Reconcile(
 		// Resource is already deleted, nothing to do
 		reconcileResult, reconcileError = ctrl.Result{}, nil
 	case v1alpha1.ResourcePhaseFailed:
-		// Resource is in failed state, nothing to do unless spec changes
-		reconcileResult, reconcileError = ctrl.Result{}, nil
+		reconcileResult, reconcileError = r.HandleFailed(ctx, obj, status)
 	}
 
 	return reconcileResult, reconcileError
@@ -189,16 +316,27 @@ func (r *Reconciler) HandlePhaseTimeout(ctx context.Context, obj client.Object,
 		return isTimeout, ctrl.Result{}, nil
 	}
 
-	elapsed := time.Since(status.PhaseStartTime.Time)
-	isTimeout = elapsed > maxPhaseTimeout
+	timeout := maxPhaseTimeout
+	if status.Phase == v1alpha1.ResourcePhaseDeleting {
+		// A retained resource stays in Deleting on purpose
+		if deletionPolicy(obj) == v1alpha1.DeletionPolicyRetain {
+			return isTimeout, ctrl.Result{}, nil
+		}
+		timeout = maxDeletionTimeout
+	}
+
+	// Outages of the API gateway do not consume the phase timeout
+	elapsed := r.phaseElapsed(ctx, status)
+	isTimeout = elapsed > timeout
 
 	if !isTimeout {
 		return isTimeout, ctrl.Result{}, nil
 	}
 
 	phaseLogger := ctrl.Log.WithValues("Phase", status.Phase, "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Name", obj.GetName())
-	message := fmt.Sprintf("Reconciliation took too much time (timeout: %+v)", maxPhaseTimeout)
+	message := fmt.Sprintf("Reconciliation took too much time (timeout: %+v)", timeout)
 	phaseLogger.Info(message)
+	metrics.PhaseTimeouts.WithLabelValues(r.kindOf(obj), string(status.Phase)).Inc()
 
 	nextCtrlResult, err := r.Next(
 		ctx,
@@ -214,6 +352,71 @@ func (r *Reconciler) HandlePhaseTimeout(ctx context.Context, obj client.Object,
 	return isTimeout, nextCtrlResult, err
 }
 
+// HandleFailed moves a failed resource back into the phase it should resume from.
+// A spec change, a pending deletion or the retry annotation trigger an immediate retry,
+// otherwise the resource is retried automatically with a capped exponential backoff.
+func (r *Reconciler) HandleFailed(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (ctrl.Result, error) {
+	phaseLogger := ctrl.Log.WithValues("Phase", status.Phase, "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Name", obj.GetName())
+
+	_, retryRequested := obj.GetAnnotations()[v1alpha1.AnnotationRetry]
+	specChanged := status.ObservedGeneration != obj.GetGeneration()
+	deletionRequested := !obj.GetDeletionTimestamp().IsZero()
+
+	if !retryRequested && !specChanged && !deletionRequested && status.PhaseStartTime != nil {
+		backoff := retryBackoff(status.RetryCount)
+		if elapsed := time.Since(status.PhaseStartTime.Time); elapsed < backoff {
+			phaseLogger.V(1).Info("Waiting before retrying failed resource", "retryCount", status.RetryCount, "backoff", backoff)
+			return ctrl.Result{RequeueAfter: backoff - elapsed}, nil
+		}
+	}
+
+	if retryRequested {
+		annotations := obj.GetAnnotations()
+		delete(annotations, v1alpha1.AnnotationRetry)
+		obj.SetAnnotations(annotations)
+		if err := r.Update(ctx, obj); err != nil {
+			phaseLogger.Error(err, "failed to remove retry annotation")
+			return ctrl.Result{}, err
+		}
+	}
+
+	var nextPhase v1alpha1.ResourcePhase
+	switch {
+	case deletionRequested:
+		nextPhase = v1alpha1.ResourcePhaseDeleting
+	case status.ResourceID == "":
+		nextPhase = v1alpha1.ResourcePhaseCreating
+	case specChanged:
+		nextPhase = v1alpha1.ResourcePhaseUpdating
+	default:
+		nextPhase = v1alpha1.ResourcePhaseProvisioning
+	}
+
+	status.RetryCount++
+	return r.Next(
+		ctx,
+		obj,
+		status,
+		nextPhase,
+		metav1.ConditionFalse,
+		"Retrying",
+		fmt.Sprintf("Retrying failed resource (attempt %d)", status.RetryCount),
+		true,
+	)
+}
+
+// retryBackoff returns the delay to wait in the Failed phase before the next automatic retry
+func retryBackoff(retryCount int32) time.Duration {
+	backoff := requeueAfter
+	for i := int32(0); i < retryCount; i++ {
+		backoff *= 2
+		if backoff >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return backoff
+}
+
 // HandleToDelete checks if resource should transition to deleting phase
 func (r *Reconciler) HandleToDelete(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus) (bool, ctrl.Result, error) {
 	shouldBeDeleted := status.Phase != v1alpha1.ResourcePhaseDeleting &&
@@ -279,7 +482,15 @@ func (r *Reconciler) Next(
 		now := metav1.Now()
 		resStatus.PhaseStartTime = &now
 	}
+	previousReason := ""
+	if condition := meta.FindStatusCondition(resStatus.Conditions, v1alpha1.ConditionTypeSynchronized); condition != nil {
+		previousReason = condition.Reason
+	}
 	resStatus.Phase = nextPhase
+	if nextPhase == v1alpha1.ResourcePhaseCreated {
+		resStatus.RetryCount = 0
+		resStatus.LastError = nil
+	}
 	resStatus.Message = message
 	resStatus.ObservedGeneration = obj.GetGeneration()
 	resStatus.Conditions = util.UpdateConditions(resStatus.Conditions, v1alpha1.ConditionTypeSynchronized, condStatus, reason, message)
@@ -288,17 +499,38 @@ func (r *Reconciler) Next(
 		phaseLogger.Error(err, "failed to update status")
 		return ctrl.Result{}, err
 	}
+	if nextPhase == v1alpha1.ResourcePhaseFailed && currentPhase != nextPhase {
+		metrics.FailedTransitions.WithLabelValues(r.kindOf(obj), reason).Inc()
+	}
+	r.recordTransition(obj, currentPhase, nextPhase, previousReason, reason, message)
 
 	phaseLogger.Info(message)
 	return ctrl.Result{Requeue: requeue, RequeueAfter: requeueAfter}, nil
 }
 
+// kindOf returns the kind of obj, which typed objects read from the cache do not carry in their TypeMeta
+func (r *Reconciler) kindOf(obj client.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return ""
+	}
+	return gvk.Kind
+}
+
 // NextToFailedOnApiError handles API errors with proper 4xx/5xx logic and condition management
 func (r *Reconciler) NextToFailedOnApiError(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, err error) (ctrl.Result, error) {
+	if result, isCircuitOpen := requeueOnCircuitOpen(err); isCircuitOpen {
+		return result, nil
+	}
+
 	var apiErr *arubaClient.ApiError
 	if errors.As(err, &apiErr) {
 		statusCode := apiErr.Status
 		message := apiErr.Error()
+		status.LastError = lastErrorStatus(apiErr)
 
 		// Handle notReady/invalidStatus errors during transitioning phases - should retry
 		if apiErr.IsInvalidStatus() {
@@ -314,6 +546,20 @@ func (r *Reconciler) NextToFailedOnApiError(ctx context.Context, obj client.Obje
 			)
 		}
 
+		// Handle 429 (rate limited) - should retry once the API accepts requests again
+		if statusCode == http.StatusTooManyRequests {
+			return r.Next(
+				ctx,
+				obj,
+				status,
+				status.Phase,
+				metav1.ConditionFalse,
+				"RateLimited",
+				fmt.Sprintf("Rate limited by the API (HTTP %d): %s - will retry", statusCode, message),
+				true,
+			)
+		}
+
 		// Handle other 4xx errors (client errors) - fail immediately
 		if statusCode >= 400 && statusCode < 500 {
 			return r.Next(
@@ -375,11 +621,70 @@ func (r *Reconciler) InitializeResource(ctx context.Context, obj client.Object,
 	return r.Next(ctx, obj, status, v1alpha1.ResourcePhaseCreating, metav1.ConditionFalse, "Initialized", "Resource initialized successfully", true)
 }
 
-// HandleDeletion handles the deletion phase with finalizer removal
-func (r *Reconciler) HandleDeletion(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, finalizerName string, deleteFunc func(context.Context) error) (ctrl.Result, error) {
-	err := deleteFunc(ctx)
-	if err != nil {
-		return r.NextToFailedOnApiError(ctx, obj, status, err)
+// HandleDeletion deletes the remote resource and removes the finalizer once it is gone.
+// The DELETE is sent once, then getFunc polls the remote resource until it returns 404,
+// so that dependents are not deleted while the remote system is still tearing it down.
+// The deletion policy annotation can orphan the remote resource or retain it by blocking the deletion.
+func (r *Reconciler) HandleDeletion(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, finalizerName string, deleteFunc func(context.Context) error, getFunc func(context.Context) error) (ctrl.Result, error) {
+	phaseLogger := ctrl.Log.WithValues("Phase", status.Phase, "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Name", obj.GetName())
+
+	policy := deletionPolicy(obj)
+	deleteRequested := meta.IsStatusConditionTrue(status.Conditions, v1alpha1.ConditionTypeDeletionInProgress)
+
+	// A DELETE already sent cannot be taken back, whatever the current policy
+	if policy == v1alpha1.DeletionPolicyRetain && !deleteRequested {
+		message := "Deletion blocked by the Retain deletion policy, set it to Delete or Orphan to proceed"
+		if status.Message != message {
+			status.Message = message
+			status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeSynchronized, metav1.ConditionFalse, "DeletionRetained", message)
+			if err := r.Client.Status().Update(ctx, obj); err != nil {
+				phaseLogger.Error(err, "failed to update status")
+				return ctrl.Result{}, err
+			}
+		}
+		phaseLogger.Info(message)
+		return ctrl.Result{}, nil
+	}
+
+	deletedMessage := "Remote resource deleted"
+	if status.ResourceID == "" {
+		deletedMessage = "Resource deleted, it was never created remotely"
+	} else if policy == v1alpha1.DeletionPolicyOrphan && !deleteRequested {
+		phaseLogger.Info("orphaning remote resource", "resourceID", status.ResourceID)
+		deletedMessage = fmt.Sprintf("Resource deleted, remote resource %s orphaned", status.ResourceID)
+	}
+
+	// Resources never created remotely or orphaned only need the finalizer removed
+	if status.ResourceID != "" && (policy != v1alpha1.DeletionPolicyOrphan || deleteRequested) {
+		if !deleteRequested {
+			err := deleteFunc(ctx)
+			if err != nil {
+				return r.NextToFailedOnApiError(ctx, obj, status, err)
+			}
+
+			message := "Waiting for the remote resource to be deleted"
+			status.Message = message
+			status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeDeletionInProgress, metav1.ConditionTrue, "DeleteRequested", message)
+			if err := r.Client.Status().Update(ctx, obj); err != nil {
+				phaseLogger.Error(err, "failed to update status")
+				return ctrl.Result{}, err
+			}
+
+			phaseLogger.Info(message)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+
+		err := getFunc(ctx)
+		if err == nil {
+			phaseLogger.Info("remote resource still exists, waiting for deletion to complete")
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+
+		var apiErr *arubaClient.ApiError
+		if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+			return r.NextToFailedOnApiError(ctx, obj, status, err)
+		}
+		phaseLogger.Info("remote resource deleted")
 	}
 
 	// Remove finalizer to allow Kubernetes to delete the resource
@@ -389,6 +694,7 @@ func (r *Reconciler) HandleDeletion(ctx context.Context, obj client.Object, stat
 		if err != nil {
 			return r.NextToFailedOnApiError(ctx, obj, status, err)
 		}
+		r.recordEvent(obj, corev1.EventTypeNormal, "Deleted", deletedMessage)
 	}
 
 	return ctrl.Result{}, nil
@@ -469,6 +775,7 @@ func (r *Reconciler) HandleProvisioning(ctx context.Context, obj client.Object,
 			true,
 		)
 	case "Failed", "Error":
+		message = fmt.Sprintf("Remote resource provisioning failed with state %s", state)
 		return r.Next(
 			ctx,
 			obj,
@@ -519,42 +826,191 @@ func (r *Reconciler) CheckForUpdates(ctx context.Context, obj client.Object, sta
 	return ctrl.Result{}, nil
 }
 
-// Authenticate authenticates the client with the given tenant
-func (r *Reconciler) Authenticate(ctx context.Context, tenantId string) error {
-	if r.Client == nil {
-		return fmt.Errorf("client configuration not loaded")
+// HandleCreated handles the steady state: spec changes move the resource to Updating, otherwise
+// the remote resource is periodically compared with the spec according to the drift policy.
+// detectDriftFunc fetches the remote resource and returns the spec fields that differ from it.
+func (r *Reconciler) HandleCreated(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, detectDriftFunc func(context.Context) ([]string, error)) (ctrl.Result, error) {
+	phaseLogger := ctrl.Log.WithValues("Phase", status.Phase, "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Name", obj.GetName())
+
+	if status.ObservedGeneration != obj.GetGeneration() {
+		return r.CheckForUpdates(ctx, obj, status)
 	}
 
-	token := r.TokenManager.GetActiveToken(tenantId)
-	if token != "" {
-		r.SetAPIToken(token)
-		return nil
+	policy := driftPolicy(obj)
+	if policy == v1alpha1.DriftPolicyIgnore {
+		phaseLogger.Info("resource is up to date")
+		return ctrl.Result{}, nil
 	}
 
-	if r.VaultIsEnabled {
-		apiKeyData, err := r.GetSecret(ctx, tenantId)
-		if err != nil {
-			ctrl.Log.Error(err, "Failed to get API key from Vault", "TenantID", tenantId)
-			return err
+	driftedFields, err := detectDriftFunc(ctx)
+	if err != nil {
+		var apiErr *arubaClient.ApiError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return r.handleRemoteNotFound(ctx, obj, status, policy)
+		}
+		// A failed drift check does not affect the resource, try again at the next interval
+		phaseLogger.Error(err, "failed to check remote resource for drift")
+		return ctrl.Result{RequeueAfter: r.driftCheckInterval()}, nil
+	}
+
+	if len(driftedFields) == 0 {
+		if meta.IsStatusConditionTrue(status.Conditions, v1alpha1.ConditionTypeDrifted) {
+			status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeDrifted, metav1.ConditionFalse, "InSync", "Remote resource matches the spec")
+			if err := r.Client.Status().Update(ctx, obj); err != nil {
+				phaseLogger.Error(err, "failed to update status")
+				return ctrl.Result{}, err
+			}
+		}
+		phaseLogger.Info("resource is up to date")
+		return ctrl.Result{RequeueAfter: r.driftCheckInterval()}, nil
+	}
+
+	message := fmt.Sprintf("Remote resource differs from spec: %s", strings.Join(driftedFields, ", "))
+	phaseLogger.Info("drift detected", "fields", driftedFields, "policy", policy)
+	status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeDrifted, metav1.ConditionTrue, "DriftDetected", message)
+
+	if policy == v1alpha1.DriftPolicyCorrect {
+		return r.Next(
+			ctx,
+			obj,
+			status,
+			v1alpha1.ResourcePhaseUpdating,
+			metav1.ConditionFalse,
+			"CorrectingDrift",
+			message,
+			true,
+		)
+	}
+
+	if err := r.Client.Status().Update(ctx, obj); err != nil {
+		phaseLogger.Error(err, "failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.driftCheckInterval()}, nil
+}
+
+// handleRemoteNotFound handles a remote resource deleted out-of-band: with the Correct policy
+// it is recreated, otherwise the resource is marked as lost and left in the Created phase
+func (r *Reconciler) handleRemoteNotFound(ctx context.Context, obj client.Object, status *v1alpha1.ResourceStatus, policy v1alpha1.DriftPolicy) (ctrl.Result, error) {
+	phaseLogger := ctrl.Log.WithValues("Phase", status.Phase, "Kind", obj.GetObjectKind().GroupVersionKind().Kind, "Name", obj.GetName())
+	message := fmt.Sprintf("Remote resource %s not found", status.ResourceID)
+	status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeDrifted, metav1.ConditionTrue, "NotFound", message)
+
+	if policy == v1alpha1.DriftPolicyCorrect {
+		status.ResourceID = ""
+		return r.Next(
+			ctx,
+			obj,
+			status,
+			v1alpha1.ResourcePhaseCreating,
+			metav1.ConditionFalse,
+			"Recreating",
+			message+", recreating it",
+			true,
+		)
+	}
+
+	phaseLogger.Info("remote resource lost", "resourceID", status.ResourceID)
+	status.Message = message
+	status.Conditions = util.UpdateConditions(status.Conditions, v1alpha1.ConditionTypeSynchronized, metav1.ConditionFalse, "ResourceLost", message)
+	if err := r.Client.Status().Update(ctx, obj); err != nil {
+		phaseLogger.Error(err, "failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.driftCheckInterval()}, nil
+}
+
+// driftPolicy returns the drift policy requested by the object annotations
+func driftPolicy(obj client.Object) v1alpha1.DriftPolicy {
+	switch policy := v1alpha1.DriftPolicy(obj.GetAnnotations()[v1alpha1.AnnotationDriftPolicy]); policy {
+	case v1alpha1.DriftPolicyIgnore, v1alpha1.DriftPolicyCorrect:
+		return policy
+	default:
+		return v1alpha1.DriftPolicyDetect
+	}
+}
+
+// deletionPolicy returns the deletion policy requested by the object annotations
+func deletionPolicy(obj client.Object) v1alpha1.DeletionPolicy {
+	switch policy := v1alpha1.DeletionPolicy(obj.GetAnnotations()[v1alpha1.AnnotationDeletionPolicy]); policy {
+	case v1alpha1.DeletionPolicyOrphan, v1alpha1.DeletionPolicyRetain:
+		return policy
+	default:
+		return v1alpha1.DeletionPolicyDelete
+	}
+}
+
+// driftCheckInterval returns the configured drift check interval or its default
+func (r *Reconciler) driftCheckInterval() time.Duration {
+	if r.DriftCheckInterval > 0 {
+		return r.DriftCheckInterval
+	}
+	return defaultDriftCheckInterval
+}
+
+// Authenticate resolves the API token for the given tenant and returns a context
+// carrying it, to be passed to every HelperClient call made for this reconcile.
+// The credentials of a tenant come from its ProviderConfig when it has one, otherwise
+// from Vault or the static configuration.
+func (r *Reconciler) Authenticate(ctx context.Context, namespace, tenantId string) (context.Context, error) {
+	if r.Client == nil {
+		return ctx, fmt.Errorf("client configuration not loaded")
+	}
+	ctx = arubaClient.WithTenant(ctx, tenantId)
+
+	providerConfig, err := r.ProviderConfigFor(ctx, tenantId)
+	if err != nil {
+		return ctx, err
+	}
+	if providerConfig != nil {
+		// Checked before the cached token, which may have been obtained for another namespace
+		if !providerConfig.AllowsNamespace(namespace) {
+			return ctx, fmt.Errorf("namespace %s is not allowed to use tenant %s by ProviderConfig %s", namespace, tenantId, providerConfig.Name)
+		}
+		if providerConfig.Spec.APIGateway != "" {
+			ctx = arubaClient.WithAPIGateway(ctx, providerConfig.Spec.APIGateway)
 		}
+	}
 
-		ctrl.Log.V(1).Info("Retrieved API key from Vault", "secretData", apiKeyData)
-		clientId, _ := apiKeyData["client-id"].(string)
-		ctrl.Log.V(1).Info("Authenticating Aruba client", "ClientID", clientId)
-		clientSecret, _ := apiKeyData["client-secret"].(string)
-		ctrl.Log.V(1).Info("Authenticating Aruba client", "ClientSecret", clientSecret)
+	token := r.TokenManager.GetActiveToken(tenantId)
+	if token != "" {
+		return arubaClient.WithAPIToken(ctx, token), nil
+	}
 
-		r.TokenManager.SetClientIdAndSecret(clientId, clientSecret)
+	switch {
+	case providerConfig != nil:
+		token, err = r.TokenManager.GetTenantToken(ctx, tenantId, r.providerConfigCredentials(providerConfig))
+	case r.VaultIsEnabled:
+		token, err = r.TokenManager.GetTenantToken(ctx, tenantId, r.vaultCredentials)
+	default:
+		token, err = r.TokenManager.GetAccessToken(ctx, false, tenantId)
+	}
+	if err != nil {
+		return ctx, err
 	}
 
-	token, err := r.TokenManager.GetAccessToken(false, tenantId)
+	return arubaClient.WithAPIToken(ctx, token), nil
+}
 
+// vaultCredentials reads the Keycloak client credentials of the tenant from Vault
+func (r *Reconciler) vaultCredentials(ctx context.Context, tenantId string) (arubaClient.Credentials, error) {
+	if arubaClient.CredentialsRejected(ctx) {
+		r.InvalidateTenantSecret(tenantId)
+	}
+	apiKeyData, err := r.GetTenantSecret(ctx, tenantId)
 	if err != nil {
-		return err
+		ctrl.Log.Error(err, "Failed to get API key from Vault", "TenantID", tenantId)
+		return arubaClient.Credentials{}, err
 	}
 
-	r.SetAPIToken(token)
-	return nil
+	clientId, _ := apiKeyData["client-id"].(string)
+	clientSecret, _ := apiKeyData["client-secret"].(string)
+	if clientId == "" || clientSecret == "" {
+		return arubaClient.Credentials{}, fmt.Errorf("client-id or client-secret missing from the Vault secret of tenant %s", tenantId)
+	}
+	ctrl.Log.V(1).Info("Authenticating Aruba client", "ClientID", clientId)
+
+	return arubaClient.Credentials{ClientID: clientId, ClientSecret: clientSecret}, nil
 }
 
 // Helper methods for getting resource references
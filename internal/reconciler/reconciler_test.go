@@ -0,0 +1,65 @@
+package reconciler_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/client"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/mocks"
+	"github.com/Arubacloud/arubacloud-resource-operator/internal/reconciler"
+)
+
+func TestReconfigure_VaultLoginFailed(t *testing.T) {
+	mockLogical := new(mocks.MockLogicalAPI)
+	mockVault := new(mocks.MockIVaultClient)
+	mockVault.On("Address").Return("https://vault.example.com")
+	mockVault.On("SetAddress", mock.Anything).Return(nil)
+	mockVault.On("Logical").Return(mockLogical)
+	mockLogical.On("Write", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("permission denied"))
+
+	mockHTTPClient := new(mocks.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.String() == "https://api.example.com/projects"
+	})).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+		Header:     make(http.Header),
+	}, nil)
+	tokenManager := new(mocks.MockITokenManager)
+
+	cfg := reconciler.ReconcilerConfig{
+		APIGateway:     "https://api.example.com",
+		VaultIsEnabled: true,
+		VaultAddress:   "https://vault.example.com",
+		RolePath:       "approle",
+		RoleID:         "role-id",
+		RoleSecret:     "role-secret",
+		KVMount:        "secret",
+		KVVersion:      2,
+		KVPathTemplate: client.DefaultPathTemplate,
+	}
+	r := &reconciler.Reconciler{
+		HelperClient:    client.NewHelperClient(nil, mockHTTPClient, cfg.APIGateway),
+		VaultAuthClient: client.NewVaultAuthClient(cfg.VaultSettings(), mockVault),
+		TokenManager:    tokenManager,
+		VaultIsEnabled:  true,
+	}
+
+	cfg.APIGateway = "https://api2.example.com"
+	cfg.VaultAddress = "https://vault2.example.com"
+	err := r.Reconfigure(cfg)
+
+	require.Error(t, err)
+	tokenManager.AssertNotCalled(t, "Reconfigure", mock.Anything, mock.Anything, mock.Anything)
+	// The requests are still sent to the previous gateway
+	err = r.DoAPIRequest(client.WithAPIToken(context.Background(), "token"), http.MethodGet, "/projects", nil, nil)
+	require.NoError(t, err)
+	mockHTTPClient.AssertExpectations(t)
+}
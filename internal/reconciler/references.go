@@ -0,0 +1,115 @@
+package reconciler
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// Field indexes on the reference fields of a spec, one per referenced kind, keyed by
+// "<namespace>/<name>" of the referenced object. Each index is registered per dependent kind.
+const (
+	ProjectReferenceIndex       = "reference.project"
+	VpcReferenceIndex           = "reference.vpc"
+	SubnetReferenceIndex        = "reference.subnet"
+	SecurityGroupReferenceIndex = "reference.securityGroup"
+	ElasticIpReferenceIndex     = "reference.elasticIp"
+	KeyPairReferenceIndex       = "reference.keyPair"
+	BlockStorageReferenceIndex  = "reference.blockStorage"
+)
+
+// ReferenceKey returns the index key of a reference, defaulting its namespace to the one of the referencing object
+func ReferenceKey(ref v1alpha1.ResourceReference, defaultNamespace string) string {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}.String()
+}
+
+// IndexReferences registers a field index on obj returning the keys of the references extracted by refsFunc
+func IndexReferences(ctx context.Context, mgr ctrl.Manager, obj client.Object, field string, refsFunc func(client.Object) []v1alpha1.ResourceReference) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, obj, field, func(o client.Object) []string {
+		refs := refsFunc(o)
+		keys := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			if ref.Name == "" {
+				continue
+			}
+			keys = append(keys, ReferenceKey(ref, o.GetNamespace()))
+		}
+		return keys
+	})
+}
+
+// EnqueueReferencing returns an event handler that enqueues every object of the list type
+// whose field index points to the object of the event
+func (r *Reconciler) EnqueueReferencing(list client.ObjectList, field string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, referenced client.Object) []reconcile.Request {
+		dependents := list.DeepCopyObject().(client.ObjectList)
+		key := types.NamespacedName{Namespace: referenced.GetNamespace(), Name: referenced.GetName()}.String()
+		if err := r.List(ctx, dependents, client.MatchingFields{field: key}); err != nil {
+			ctrl.Log.Error(err, "failed to list dependents of referenced resource", "Field", field, "Referenced", key)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, item := range extractItems(dependents) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetName()},
+			})
+		}
+		return requests
+	})
+}
+
+// ReferencedStatusChanged returns the builder option used when watching referenced resources:
+// dependents only care about a referenced object when its phase or remote ID changes
+func ReferencedStatusChanged() builder.WatchesOption {
+	return builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPhase, oldID := phaseAndResourceID(e.ObjectOld)
+			newPhase, newID := phaseAndResourceID(e.ObjectNew)
+			return oldPhase != newPhase || oldID != newID
+		},
+	})
+}
+
+// phaseAndResourceID reads status.phase and status.resourceID of any resource of this API group
+func phaseAndResourceID(obj client.Object) (string, string) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", ""
+	}
+	phase, _, _ := unstructured.NestedString(content, "status", "phase")
+	resourceID, _, _ := unstructured.NestedString(content, "status", "resourceID")
+	return phase, resourceID
+}
+
+// extractItems returns the items of a typed list as client objects
+func extractItems(list client.ObjectList) []client.Object {
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil
+	}
+
+	items := make([]client.Object, 0, len(objs))
+	for _, o := range objs {
+		if item, ok := o.(client.Object); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -16,6 +16,9 @@ func UpdateConditions(conditions []metav1.Condition, conditionType string, statu
 				conditions[i].Reason = reason
 				conditions[i].Message = message
 				conditions[i].LastTransitionTime = now
+			} else if condition.Message != message {
+				// Keep the transition time, only the details changed
+				conditions[i].Message = message
 			}
 			return conditions
 		}
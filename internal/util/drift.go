@@ -0,0 +1,37 @@
+package util
+
+import "path"
+
+// SameElements reports whether a and b contain the same strings, ignoring order and duplicates
+func SameElements(a, b []string) bool {
+	aMap := make(map[string]bool, len(a))
+	bMap := make(map[string]bool, len(b))
+
+	for _, v := range a {
+		aMap[v] = true
+	}
+
+	for _, v := range b {
+		bMap[v] = true
+	}
+
+	if len(aMap) != len(bMap) {
+		return false
+	}
+
+	for v := range aMap {
+		if !bMap[v] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IDFromURI returns the resource ID, i.e. the last path segment, of a CMP resource URI
+func IDFromURI(uri string) string {
+	if uri == "" {
+		return ""
+	}
+	return path.Base(uri)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var blockstoragelog = logf.Log.WithName("blockstorage-resource")
+
+// SetupBlockStorageWebhookWithManager registers the webhook for BlockStorage in the manager.
+func SetupBlockStorageWebhookWithManager(mgr ctrl.Manager, validationOptions ValidationOptions, defaultingOptions DefaultingOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.BlockStorage{}).
+		WithValidator(&BlockStorageCustomValidator{ValidationOptions: validationOptions}).
+		WithDefaulter(&BlockStorageCustomDefaulter{resourceDefaulter{client: mgr.GetClient(), DefaultingOptions: defaultingOptions}}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-blockstorage,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=blockstorages,verbs=create,versions=v1alpha1,name=mblockstorage-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BlockStorageCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind BlockStorage when those are created.
+type BlockStorageCustomDefaulter struct {
+	resourceDefaulter
+}
+
+var _ webhook.CustomDefaulter = &BlockStorageCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind BlockStorage.
+func (d *BlockStorageCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	blockStorage, ok := obj.(*arubacloudcomv1alpha1.BlockStorage)
+	if !ok {
+		return fmt.Errorf("expected a BlockStorage object but got %T", obj)
+	}
+	blockstoragelog.Info("Defaulting for BlockStorage", "name", blockStorage.GetName())
+
+	defaults, err := d.resolve(ctx, blockStorage.Namespace, &blockStorage.Spec.ProjectReference)
+	if err != nil {
+		return err
+	}
+
+	defaultString(&blockStorage.Spec.Tenant, defaults.Tenant)
+	defaultString(&blockStorage.Spec.Location.Value, defaults.Location)
+	defaultString(&blockStorage.Spec.DataCenter, defaults.DataCenter)
+	defaultReference(&blockStorage.Spec.ProjectReference, blockStorage.Namespace)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-blockstorage,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=blockstorages,verbs=create;update,versions=v1alpha1,name=vblockstorage-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BlockStorageCustomValidator struct is responsible for validating the BlockStorage resource
+// when it is created, updated, or deleted.
+type BlockStorageCustomValidator struct {
+	ValidationOptions
+}
+
+var _ webhook.CustomValidator = &BlockStorageCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type BlockStorage.
+func (v *BlockStorageCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	blockStorage, ok := obj.(*arubacloudcomv1alpha1.BlockStorage)
+	if !ok {
+		return nil, fmt.Errorf("expected a BlockStorage object but got %T", obj)
+	}
+	blockstoragelog.Info("Validation for BlockStorage upon creation", "name", blockStorage.GetName())
+
+	return nil, invalid("BlockStorage", blockStorage.Name, v.validateBlockStorage(blockStorage))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type BlockStorage.
+func (v *BlockStorageCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	blockStorage, ok := newObj.(*arubacloudcomv1alpha1.BlockStorage)
+	if !ok {
+		return nil, fmt.Errorf("expected a BlockStorage object for the newObj but got %T", newObj)
+	}
+	oldBlockStorage, ok := oldObj.(*arubacloudcomv1alpha1.BlockStorage)
+	if !ok {
+		return nil, fmt.Errorf("expected a BlockStorage object for the oldObj but got %T", oldObj)
+	}
+	blockstoragelog.Info("Validation for BlockStorage upon update", "name", blockStorage.GetName())
+
+	// Never block the finalizer removal of an object being deleted
+	if !blockStorage.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	allErrs := v.validateBlockStorage(blockStorage)
+
+	// Fields can still be fixed until the remote resource has been created
+	if oldBlockStorage.Status.ResourceID != "" {
+		specPath := field.NewPath("spec")
+		allErrs = append(allErrs, validateTenant(specPath.Child("tenant"), blockStorage.Spec.Tenant, oldBlockStorage.Spec.Tenant)...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(blockStorage.Spec.Location, oldBlockStorage.Spec.Location, specPath.Child("location"))...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(blockStorage.Spec.DataCenter, oldBlockStorage.Spec.DataCenter, specPath.Child("dataCenter"))...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(blockStorage.Spec.Bootable, oldBlockStorage.Spec.Bootable, specPath.Child("bootable"))...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(blockStorage.Spec.Image, oldBlockStorage.Spec.Image, specPath.Child("image"))...)
+		if blockStorage.Spec.SizeGb < oldBlockStorage.Spec.SizeGb {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("sizeGb"), blockStorage.Spec.SizeGb, "volumes can only be expanded"))
+		}
+	}
+
+	return nil, invalid("BlockStorage", blockStorage.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type BlockStorage.
+func (v *BlockStorageCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	blockStorage, ok := obj.(*arubacloudcomv1alpha1.BlockStorage)
+	if !ok {
+		return nil, fmt.Errorf("expected a BlockStorage object but got %T", obj)
+	}
+	blockstoragelog.Info("Validation for BlockStorage upon deletion", "name", blockStorage.GetName())
+
+	return nil, nil
+}
+
+// validateBlockStorage checks the fields of a BlockStorage that must hold on create and update
+func (v *BlockStorageCustomValidator) validateBlockStorage(blockStorage *arubacloudcomv1alpha1.BlockStorage) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, v.validateReference(specPath.Child("projectReference"), blockStorage.Spec.ProjectReference, blockStorage.Namespace)...)
+	return allErrs
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("BlockStorage Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.BlockStorage
+		oldObj    *arubacloudcomv1alpha1.BlockStorage
+		validator BlockStorageCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		oldObj = &arubacloudcomv1alpha1.BlockStorage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-blockstorage",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.BlockStorageSpec{
+				Tenant:        "test-tenant",
+				Location:      arubacloudcomv1alpha1.Location{Value: "ITBG-Bergamo"},
+				SizeGb:        20,
+				BillingPeriod: "Hour",
+				DataCenter:    "ITBG-1",
+				ProjectReference: arubacloudcomv1alpha1.ResourceReference{
+					Name: "test-project",
+				},
+			},
+			Status: arubacloudcomv1alpha1.BlockStorageStatus{
+				ResourceStatus: arubacloudcomv1alpha1.ResourceStatus{
+					ResourceID: "blockstorage-123",
+				},
+			},
+		}
+		obj = oldObj.DeepCopy()
+		validator = BlockStorageCustomValidator{}
+	})
+
+	Context("When updating BlockStorage under Validating Webhook", func() {
+		It("Should allow expanding the volume", func() {
+			obj.Spec.SizeGb = 40
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny shrinking the volume", func() {
+			obj.Spec.SizeGb = 10
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(MatchError(ContainSubstring("spec.sizeGb")))
+		})
+
+		It("Should deny changing immutable fields", func() {
+			obj.Spec.DataCenter = "ITBG-2"
+			obj.Spec.Location.Value = "ITMI-Milano"
+			obj.Spec.Bootable = true
+			obj.Spec.Image = "ubuntu"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.dataCenter")))
+			Expect(err).To(MatchError(ContainSubstring("spec.location")))
+			Expect(err).To(MatchError(ContainSubstring("spec.bootable")))
+			Expect(err).To(MatchError(ContainSubstring("spec.image")))
+		})
+
+		It("Should not block updates of an object being deleted", func() {
+			now := metav1.Now()
+			obj.DeletionTimestamp = &now
+			obj.Spec.SizeGb = 10
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When creating BlockStorage under Defaulting Webhook", func() {
+		var defaulter BlockStorageCustomDefaulter
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(arubacloudcomv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &arubacloudcomv1alpha1.Project{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-project",
+					Namespace: "default",
+					Annotations: map[string]string{
+						arubacloudcomv1alpha1.AnnotationDefaultLocation: "ITBG-Bergamo",
+					},
+				},
+				Spec: arubacloudcomv1alpha1.ProjectSpec{Tenant: "project-tenant"},
+			}
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+					Annotations: map[string]string{
+						arubacloudcomv1alpha1.AnnotationDefaultLocation:   "ITMI-Milano",
+						arubacloudcomv1alpha1.AnnotationDefaultDataCenter: "ITBG-1",
+					},
+				},
+			}
+			defaulter = BlockStorageCustomDefaulter{resourceDefaulter{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, namespace).Build(),
+				DefaultingOptions: DefaultingOptions{
+					Tenant:     "operator-tenant",
+					Location:   "ITAR-Arezzo",
+					DataCenter: "ITAR-1",
+				},
+			}}
+
+			obj = &arubacloudcomv1alpha1.BlockStorage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-blockstorage",
+					Namespace: "default",
+				},
+				Spec: arubacloudcomv1alpha1.BlockStorageSpec{
+					SizeGb:           20,
+					BillingPeriod:    "Hour",
+					ProjectReference: arubacloudcomv1alpha1.ResourceReference{Name: "test-project"},
+				},
+			}
+		})
+
+		It("Should fill in defaults from the project first, then the namespace", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Tenant).To(Equal("project-tenant"))
+			Expect(obj.Spec.Location.Value).To(Equal("ITBG-Bergamo"))
+			Expect(obj.Spec.DataCenter).To(Equal("ITBG-1"))
+			Expect(obj.Spec.ProjectReference.Namespace).To(Equal("default"))
+		})
+
+		It("Should fall back to the operator defaults when the project does not exist", func() {
+			obj.Spec.ProjectReference.Name = "missing-project"
+			obj.Namespace = "other"
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Tenant).To(Equal("operator-tenant"))
+			Expect(obj.Spec.Location.Value).To(Equal("ITAR-Arezzo"))
+			Expect(obj.Spec.DataCenter).To(Equal("ITAR-1"))
+			Expect(obj.Spec.ProjectReference.Namespace).To(Equal("other"))
+		})
+
+		It("Should keep the values set in the spec", func() {
+			obj.Spec.Tenant = "spec-tenant"
+			obj.Spec.DataCenter = "ITBG-3"
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.Tenant).To(Equal("spec-tenant"))
+			Expect(obj.Spec.DataCenter).To(Equal("ITBG-3"))
+		})
+	})
+})
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var cloudserverlog = logf.Log.WithName("cloudserver-resource")
+
+// SetupCloudServerWebhookWithManager registers the webhook for CloudServer in the manager.
+func SetupCloudServerWebhookWithManager(mgr ctrl.Manager, validationOptions ValidationOptions, defaultingOptions DefaultingOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.CloudServer{}).
+		WithValidator(&CloudServerCustomValidator{ValidationOptions: validationOptions}).
+		WithDefaulter(&CloudServerCustomDefaulter{resourceDefaulter{client: mgr.GetClient(), DefaultingOptions: defaultingOptions}}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-cloudserver,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=cloudservers,verbs=create,versions=v1alpha1,name=mcloudserver-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// CloudServerCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind CloudServer when those are created.
+type CloudServerCustomDefaulter struct {
+	resourceDefaulter
+}
+
+var _ webhook.CustomDefaulter = &CloudServerCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind CloudServer.
+func (d *CloudServerCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	cloudServer, ok := obj.(*arubacloudcomv1alpha1.CloudServer)
+	if !ok {
+		return fmt.Errorf("expected a CloudServer object but got %T", obj)
+	}
+	cloudserverlog.Info("Defaulting for CloudServer", "name", cloudServer.GetName())
+
+	defaults, err := d.resolve(ctx, cloudServer.Namespace, &cloudServer.Spec.ProjectReference)
+	if err != nil {
+		return err
+	}
+
+	defaultString(&cloudServer.Spec.Tenant, defaults.Tenant)
+	defaultString(&cloudServer.Spec.Location.Value, defaults.Location)
+	defaultString(&cloudServer.Spec.DataCenter, defaults.DataCenter)
+	defaultReference(&cloudServer.Spec.VpcReference, cloudServer.Namespace)
+	if cloudServer.Spec.ElasticIpReference != nil {
+		defaultReference(cloudServer.Spec.ElasticIpReference, cloudServer.Namespace)
+	}
+	defaultReference(&cloudServer.Spec.KeyPairReference, cloudServer.Namespace)
+	defaultReferences(cloudServer.Spec.SubnetReferences, cloudServer.Namespace)
+	defaultReferences(cloudServer.Spec.SecurityGroupReferences, cloudServer.Namespace)
+	defaultReference(&cloudServer.Spec.BootVolumeReference, cloudServer.Namespace)
+	defaultReferences(cloudServer.Spec.DataVolumeReferences, cloudServer.Namespace)
+	defaultReference(&cloudServer.Spec.ProjectReference, cloudServer.Namespace)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-cloudserver,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=cloudservers,verbs=create;update,versions=v1alpha1,name=vcloudserver-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// CloudServerCustomValidator struct is responsible for validating the CloudServer resource
+// when it is created, updated, or deleted.
+type CloudServerCustomValidator struct {
+	ValidationOptions
+}
+
+var _ webhook.CustomValidator = &CloudServerCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type CloudServer.
+func (v *CloudServerCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cloudServer, ok := obj.(*arubacloudcomv1alpha1.CloudServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a CloudServer object but got %T", obj)
+	}
+	cloudserverlog.Info("Validation for CloudServer upon creation", "name", cloudServer.GetName())
+
+	return nil, invalid("CloudServer", cloudServer.Name, v.validateCloudServer(cloudServer))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type CloudServer.
+func (v *CloudServerCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	cloudServer, ok := newObj.(*arubacloudcomv1alpha1.CloudServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a CloudServer object for the newObj but got %T", newObj)
+	}
+	oldCloudServer, ok := oldObj.(*arubacloudcomv1alpha1.CloudServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a CloudServer object for the oldObj but got %T", oldObj)
+	}
+	cloudserverlog.Info("Validation for CloudServer upon update", "name", cloudServer.GetName())
+
+	// Never block the finalizer removal of an object being deleted
+	if !cloudServer.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	allErrs := v.validateCloudServer(cloudServer)
+
+	// Fields can still be fixed until the remote resource has been created
+	if oldCloudServer.Status.ResourceID != "" {
+		specPath := field.NewPath("spec")
+		allErrs = append(allErrs, validateTenant(specPath.Child("tenant"), cloudServer.Spec.Tenant, oldCloudServer.Spec.Tenant)...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(cloudServer.Spec.Location, oldCloudServer.Spec.Location, specPath.Child("location"))...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(cloudServer.Spec.DataCenter, oldCloudServer.Spec.DataCenter, specPath.Child("dataCenter"))...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(cloudServer.Spec.VpcReference, oldCloudServer.Spec.VpcReference, specPath.Child("vpcReference"))...)
+	}
+
+	return nil, invalid("CloudServer", cloudServer.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type CloudServer.
+func (v *CloudServerCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cloudServer, ok := obj.(*arubacloudcomv1alpha1.CloudServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a CloudServer object but got %T", obj)
+	}
+	cloudserverlog.Info("Validation for CloudServer upon deletion", "name", cloudServer.GetName())
+
+	return nil, nil
+}
+
+// validateCloudServer checks the fields of a CloudServer that must hold on create and update
+func (v *CloudServerCustomValidator) validateCloudServer(cloudServer *arubacloudcomv1alpha1.CloudServer) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, v.validateReference(specPath.Child("vpcReference"), cloudServer.Spec.VpcReference, cloudServer.Namespace)...)
+	if cloudServer.Spec.ElasticIpReference != nil {
+		allErrs = append(allErrs, v.validateReference(specPath.Child("elasticIpReference"), *cloudServer.Spec.ElasticIpReference, cloudServer.Namespace)...)
+	}
+	allErrs = append(allErrs, v.validateReference(specPath.Child("keyPairReference"), cloudServer.Spec.KeyPairReference, cloudServer.Namespace)...)
+	allErrs = append(allErrs, v.validateReferences(specPath.Child("subnetReferences"), cloudServer.Spec.SubnetReferences, cloudServer.Namespace)...)
+	allErrs = append(allErrs, v.validateReferences(specPath.Child("securityGroupReferences"), cloudServer.Spec.SecurityGroupReferences, cloudServer.Namespace)...)
+	allErrs = append(allErrs, v.validateReference(specPath.Child("bootVolumeReference"), cloudServer.Spec.BootVolumeReference, cloudServer.Namespace)...)
+	allErrs = append(allErrs, v.validateReferences(specPath.Child("dataVolumeReferences"), cloudServer.Spec.DataVolumeReferences, cloudServer.Namespace)...)
+	allErrs = append(allErrs, v.validateReference(specPath.Child("projectReference"), cloudServer.Spec.ProjectReference, cloudServer.Namespace)...)
+	return allErrs
+}
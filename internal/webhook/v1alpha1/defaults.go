@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// DefaultingOptions holds the operator-wide defaults, used when neither the Project nor the namespace provide a value
+type DefaultingOptions struct {
+	Tenant     string
+	Location   string
+	DataCenter string
+}
+
+// resourceDefaulter resolves the defaults of an object, in order of precedence from its Project,
+// from the annotations of its namespace and from the operator configuration
+type resourceDefaulter struct {
+	client client.Reader
+	DefaultingOptions
+}
+
+// resolve returns the defaults for an object in namespace referencing the given Project, if any
+func (d *resourceDefaulter) resolve(ctx context.Context, namespace string, projectRef *arubacloudcomv1alpha1.ResourceReference) (DefaultingOptions, error) {
+	var sources []DefaultingOptions
+
+	if projectRef != nil && projectRef.Name != "" {
+		projectNamespace := projectRef.Namespace
+		if projectNamespace == "" {
+			projectNamespace = namespace
+		}
+		project := &arubacloudcomv1alpha1.Project{}
+		err := d.client.Get(ctx, types.NamespacedName{Namespace: projectNamespace, Name: projectRef.Name}, project)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return DefaultingOptions{}, fmt.Errorf("failed to get project %s/%s: %w", projectNamespace, projectRef.Name, err)
+		}
+		if err == nil {
+			projectDefaults := annotationDefaults(project.Annotations)
+			if project.Spec.Tenant != "" {
+				projectDefaults.Tenant = project.Spec.Tenant
+			}
+			sources = append(sources, projectDefaults)
+		}
+	}
+
+	ns := &corev1.Namespace{}
+	err := d.client.Get(ctx, types.NamespacedName{Name: namespace}, ns)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return DefaultingOptions{}, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	if err == nil {
+		sources = append(sources, annotationDefaults(ns.Annotations))
+	}
+
+	sources = append(sources, d.DefaultingOptions)
+
+	var defaults DefaultingOptions
+	for _, source := range sources {
+		defaultString(&defaults.Tenant, source.Tenant)
+		defaultString(&defaults.Location, source.Location)
+		defaultString(&defaults.DataCenter, source.DataCenter)
+	}
+	return defaults, nil
+}
+
+// annotationDefaults reads the default annotations of a Project or a Namespace
+func annotationDefaults(annotations map[string]string) DefaultingOptions {
+	return DefaultingOptions{
+		Tenant:     annotations[arubacloudcomv1alpha1.AnnotationDefaultTenant],
+		Location:   annotations[arubacloudcomv1alpha1.AnnotationDefaultLocation],
+		DataCenter: annotations[arubacloudcomv1alpha1.AnnotationDefaultDataCenter],
+	}
+}
+
+// defaultString sets value to def when it is empty
+func defaultString(value *string, def string) {
+	if *value == "" {
+		*value = def
+	}
+}
+
+// defaultReference sets the namespace of a reference to the namespace of the referencing object
+func defaultReference(ref *arubacloudcomv1alpha1.ResourceReference, namespace string) {
+	if ref.Name != "" {
+		defaultString(&ref.Namespace, namespace)
+	}
+}
+
+// defaultReferences sets the namespace of a list of references with defaultReference
+func defaultReferences(refs []arubacloudcomv1alpha1.ResourceReference, namespace string) {
+	for i := range refs {
+		defaultReference(&refs[i], namespace)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var elasticiplog = logf.Log.WithName("elasticip-resource")
+
+// SetupElasticIpWebhookWithManager registers the webhook for ElasticIp in the manager.
+func SetupElasticIpWebhookWithManager(mgr ctrl.Manager, validationOptions ValidationOptions, defaultingOptions DefaultingOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.ElasticIp{}).
+		WithValidator(&ElasticIpCustomValidator{ValidationOptions: validationOptions}).
+		WithDefaulter(&ElasticIpCustomDefaulter{resourceDefaulter{client: mgr.GetClient(), DefaultingOptions: defaultingOptions}}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-elasticip,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=elasticips,verbs=create,versions=v1alpha1,name=melasticip-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ElasticIpCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind ElasticIp when those are created.
+type ElasticIpCustomDefaulter struct {
+	resourceDefaulter
+}
+
+var _ webhook.CustomDefaulter = &ElasticIpCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind ElasticIp.
+func (d *ElasticIpCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	elasticIp, ok := obj.(*arubacloudcomv1alpha1.ElasticIp)
+	if !ok {
+		return fmt.Errorf("expected a ElasticIp object but got %T", obj)
+	}
+	elasticiplog.Info("Defaulting for ElasticIp", "name", elasticIp.GetName())
+
+	defaults, err := d.resolve(ctx, elasticIp.Namespace, &elasticIp.Spec.ProjectReference)
+	if err != nil {
+		return err
+	}
+
+	defaultString(&elasticIp.Spec.Tenant, defaults.Tenant)
+	defaultString(&elasticIp.Spec.Location.Value, defaults.Location)
+	defaultReference(&elasticIp.Spec.ProjectReference, elasticIp.Namespace)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-elasticip,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=elasticips,verbs=create;update,versions=v1alpha1,name=velasticip-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ElasticIpCustomValidator struct is responsible for validating the ElasticIp resource
+// when it is created, updated, or deleted.
+type ElasticIpCustomValidator struct {
+	ValidationOptions
+}
+
+var _ webhook.CustomValidator = &ElasticIpCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ElasticIp.
+func (v *ElasticIpCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	elasticIp, ok := obj.(*arubacloudcomv1alpha1.ElasticIp)
+	if !ok {
+		return nil, fmt.Errorf("expected a ElasticIp object but got %T", obj)
+	}
+	elasticiplog.Info("Validation for ElasticIp upon creation", "name", elasticIp.GetName())
+
+	return nil, invalid("ElasticIp", elasticIp.Name, v.validateElasticIp(elasticIp))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ElasticIp.
+func (v *ElasticIpCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	elasticIp, ok := newObj.(*arubacloudcomv1alpha1.ElasticIp)
+	if !ok {
+		return nil, fmt.Errorf("expected a ElasticIp object for the newObj but got %T", newObj)
+	}
+	oldElasticIp, ok := oldObj.(*arubacloudcomv1alpha1.ElasticIp)
+	if !ok {
+		return nil, fmt.Errorf("expected a ElasticIp object for the oldObj but got %T", oldObj)
+	}
+	elasticiplog.Info("Validation for ElasticIp upon update", "name", elasticIp.GetName())
+
+	// Never block the finalizer removal of an object being deleted
+	if !elasticIp.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	allErrs := v.validateElasticIp(elasticIp)
+
+	// Fields can still be fixed until the remote resource has been created
+	if oldElasticIp.Status.ResourceID != "" {
+		specPath := field.NewPath("spec")
+		allErrs = append(allErrs, validateTenant(specPath.Child("tenant"), elasticIp.Spec.Tenant, oldElasticIp.Spec.Tenant)...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(elasticIp.Spec.Location, oldElasticIp.Spec.Location, specPath.Child("location"))...)
+	}
+
+	return nil, invalid("ElasticIp", elasticIp.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ElasticIp.
+func (v *ElasticIpCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	elasticIp, ok := obj.(*arubacloudcomv1alpha1.ElasticIp)
+	if !ok {
+		return nil, fmt.Errorf("expected a ElasticIp object but got %T", obj)
+	}
+	elasticiplog.Info("Validation for ElasticIp upon deletion", "name", elasticIp.GetName())
+
+	return nil, nil
+}
+
+// validateElasticIp checks the fields of a ElasticIp that must hold on create and update
+func (v *ElasticIpCustomValidator) validateElasticIp(elasticIp *arubacloudcomv1alpha1.ElasticIp) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, v.validateReference(specPath.Child("projectReference"), elasticIp.Spec.ProjectReference, elasticIp.Namespace)...)
+	return allErrs
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var keypairlog = logf.Log.WithName("keypair-resource")
+
+// SetupKeyPairWebhookWithManager registers the webhook for KeyPair in the manager.
+func SetupKeyPairWebhookWithManager(mgr ctrl.Manager, validationOptions ValidationOptions, defaultingOptions DefaultingOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.KeyPair{}).
+		WithValidator(&KeyPairCustomValidator{ValidationOptions: validationOptions}).
+		WithDefaulter(&KeyPairCustomDefaulter{resourceDefaulter{client: mgr.GetClient(), DefaultingOptions: defaultingOptions}}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-keypair,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=keypairs,verbs=create,versions=v1alpha1,name=mkeypair-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KeyPairCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind KeyPair when those are created.
+type KeyPairCustomDefaulter struct {
+	resourceDefaulter
+}
+
+var _ webhook.CustomDefaulter = &KeyPairCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind KeyPair.
+func (d *KeyPairCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	keyPair, ok := obj.(*arubacloudcomv1alpha1.KeyPair)
+	if !ok {
+		return fmt.Errorf("expected a KeyPair object but got %T", obj)
+	}
+	keypairlog.Info("Defaulting for KeyPair", "name", keyPair.GetName())
+
+	defaults, err := d.resolve(ctx, keyPair.Namespace, &keyPair.Spec.ProjectReference)
+	if err != nil {
+		return err
+	}
+
+	defaultString(&keyPair.Spec.Tenant, defaults.Tenant)
+	defaultString(&keyPair.Spec.Location.Value, defaults.Location)
+	defaultReference(&keyPair.Spec.ProjectReference, keyPair.Namespace)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-keypair,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=keypairs,verbs=create;update,versions=v1alpha1,name=vkeypair-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KeyPairCustomValidator struct is responsible for validating the KeyPair resource
+// when it is created, updated, or deleted.
+type KeyPairCustomValidator struct {
+	ValidationOptions
+}
+
+var _ webhook.CustomValidator = &KeyPairCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type KeyPair.
+func (v *KeyPairCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	keyPair, ok := obj.(*arubacloudcomv1alpha1.KeyPair)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeyPair object but got %T", obj)
+	}
+	keypairlog.Info("Validation for KeyPair upon creation", "name", keyPair.GetName())
+
+	return nil, invalid("KeyPair", keyPair.Name, v.validateKeyPair(keyPair))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type KeyPair.
+func (v *KeyPairCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	keyPair, ok := newObj.(*arubacloudcomv1alpha1.KeyPair)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeyPair object for the newObj but got %T", newObj)
+	}
+	oldKeyPair, ok := oldObj.(*arubacloudcomv1alpha1.KeyPair)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeyPair object for the oldObj but got %T", oldObj)
+	}
+	keypairlog.Info("Validation for KeyPair upon update", "name", keyPair.GetName())
+
+	// Never block the finalizer removal of an object being deleted
+	if !keyPair.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	allErrs := v.validateKeyPair(keyPair)
+
+	// Fields can still be fixed until the remote resource has been created
+	if oldKeyPair.Status.ResourceID != "" {
+		specPath := field.NewPath("spec")
+		allErrs = append(allErrs, validateTenant(specPath.Child("tenant"), keyPair.Spec.Tenant, oldKeyPair.Spec.Tenant)...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(keyPair.Spec.Location, oldKeyPair.Spec.Location, specPath.Child("location"))...)
+	}
+
+	return nil, invalid("KeyPair", keyPair.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type KeyPair.
+func (v *KeyPairCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	keyPair, ok := obj.(*arubacloudcomv1alpha1.KeyPair)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeyPair object but got %T", obj)
+	}
+	keypairlog.Info("Validation for KeyPair upon deletion", "name", keyPair.GetName())
+
+	return nil, nil
+}
+
+// validateKeyPair checks the fields of a KeyPair that must hold on create and update
+func (v *KeyPairCustomValidator) validateKeyPair(keyPair *arubacloudcomv1alpha1.KeyPair) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, v.validateReference(specPath.Child("projectReference"), keyPair.Spec.ProjectReference, keyPair.Namespace)...)
+	return allErrs
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var projectlog = logf.Log.WithName("project-resource")
+
+// SetupProjectWebhookWithManager registers the webhook for Project in the manager.
+func SetupProjectWebhookWithManager(mgr ctrl.Manager, validationOptions ValidationOptions, defaultingOptions DefaultingOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.Project{}).
+		WithValidator(&ProjectCustomValidator{ValidationOptions: validationOptions}).
+		WithDefaulter(&ProjectCustomDefaulter{resourceDefaulter{client: mgr.GetClient(), DefaultingOptions: defaultingOptions}}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-project,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=projects,verbs=create,versions=v1alpha1,name=mproject-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ProjectCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind Project when those are created.
+type ProjectCustomDefaulter struct {
+	resourceDefaulter
+}
+
+var _ webhook.CustomDefaulter = &ProjectCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind Project.
+func (d *ProjectCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	project, ok := obj.(*arubacloudcomv1alpha1.Project)
+	if !ok {
+		return fmt.Errorf("expected a Project object but got %T", obj)
+	}
+	projectlog.Info("Defaulting for Project", "name", project.GetName())
+
+	defaults, err := d.resolve(ctx, project.Namespace, nil)
+	if err != nil {
+		return err
+	}
+
+	defaultString(&project.Spec.Tenant, defaults.Tenant)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-project,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=projects,verbs=create;update,versions=v1alpha1,name=vproject-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ProjectCustomValidator struct is responsible for validating the Project resource
+// when it is created, updated, or deleted.
+type ProjectCustomValidator struct {
+	ValidationOptions
+}
+
+var _ webhook.CustomValidator = &ProjectCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Project.
+func (v *ProjectCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	project, ok := obj.(*arubacloudcomv1alpha1.Project)
+	if !ok {
+		return nil, fmt.Errorf("expected a Project object but got %T", obj)
+	}
+	projectlog.Info("Validation for Project upon creation", "name", project.GetName())
+
+	return nil, invalid("Project", project.Name, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Project.
+func (v *ProjectCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	project, ok := newObj.(*arubacloudcomv1alpha1.Project)
+	if !ok {
+		return nil, fmt.Errorf("expected a Project object for the newObj but got %T", newObj)
+	}
+	oldProject, ok := oldObj.(*arubacloudcomv1alpha1.Project)
+	if !ok {
+		return nil, fmt.Errorf("expected a Project object for the oldObj but got %T", oldObj)
+	}
+	projectlog.Info("Validation for Project upon update", "name", project.GetName())
+
+	// Never block the finalizer removal of an object being deleted
+	if !project.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	var allErrs field.ErrorList
+
+	// Fields can still be fixed until the remote resource has been created
+	if oldProject.Status.ResourceID != "" {
+		specPath := field.NewPath("spec")
+		allErrs = append(allErrs, validateTenant(specPath.Child("tenant"), project.Spec.Tenant, oldProject.Spec.Tenant)...)
+	}
+
+	return nil, invalid("Project", project.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Project.
+func (v *ProjectCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	project, ok := obj.(*arubacloudcomv1alpha1.Project)
+	if !ok {
+		return nil, fmt.Errorf("expected a Project object but got %T", obj)
+	}
+	projectlog.Info("Validation for Project upon deletion", "name", project.GetName())
+
+	return nil, nil
+}
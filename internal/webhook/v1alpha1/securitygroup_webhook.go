@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var securitygrouplog = logf.Log.WithName("securitygroup-resource")
+
+// SetupSecurityGroupWebhookWithManager registers the webhook for SecurityGroup in the manager.
+func SetupSecurityGroupWebhookWithManager(mgr ctrl.Manager, validationOptions ValidationOptions, defaultingOptions DefaultingOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.SecurityGroup{}).
+		WithValidator(&SecurityGroupCustomValidator{ValidationOptions: validationOptions}).
+		WithDefaulter(&SecurityGroupCustomDefaulter{resourceDefaulter{client: mgr.GetClient(), DefaultingOptions: defaultingOptions}}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-securitygroup,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=securitygroups,verbs=create,versions=v1alpha1,name=msecuritygroup-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecurityGroupCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind SecurityGroup when those are created.
+type SecurityGroupCustomDefaulter struct {
+	resourceDefaulter
+}
+
+var _ webhook.CustomDefaulter = &SecurityGroupCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind SecurityGroup.
+func (d *SecurityGroupCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	securityGroup, ok := obj.(*arubacloudcomv1alpha1.SecurityGroup)
+	if !ok {
+		return fmt.Errorf("expected a SecurityGroup object but got %T", obj)
+	}
+	securitygrouplog.Info("Defaulting for SecurityGroup", "name", securityGroup.GetName())
+
+	defaults, err := d.resolve(ctx, securityGroup.Namespace, &securityGroup.Spec.ProjectReference)
+	if err != nil {
+		return err
+	}
+
+	defaultString(&securityGroup.Spec.Tenant, defaults.Tenant)
+	defaultString(&securityGroup.Spec.Location.Value, defaults.Location)
+	defaultReference(&securityGroup.Spec.VpcReference, securityGroup.Namespace)
+	defaultReference(&securityGroup.Spec.ProjectReference, securityGroup.Namespace)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-securitygroup,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=securitygroups,verbs=create;update,versions=v1alpha1,name=vsecuritygroup-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecurityGroupCustomValidator struct is responsible for validating the SecurityGroup resource
+// when it is created, updated, or deleted.
+type SecurityGroupCustomValidator struct {
+	ValidationOptions
+}
+
+var _ webhook.CustomValidator = &SecurityGroupCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type SecurityGroup.
+func (v *SecurityGroupCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	securityGroup, ok := obj.(*arubacloudcomv1alpha1.SecurityGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityGroup object but got %T", obj)
+	}
+	securitygrouplog.Info("Validation for SecurityGroup upon creation", "name", securityGroup.GetName())
+
+	return nil, invalid("SecurityGroup", securityGroup.Name, v.validateSecurityGroup(securityGroup))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type SecurityGroup.
+func (v *SecurityGroupCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	securityGroup, ok := newObj.(*arubacloudcomv1alpha1.SecurityGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityGroup object for the newObj but got %T", newObj)
+	}
+	oldSecurityGroup, ok := oldObj.(*arubacloudcomv1alpha1.SecurityGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityGroup object for the oldObj but got %T", oldObj)
+	}
+	securitygrouplog.Info("Validation for SecurityGroup upon update", "name", securityGroup.GetName())
+
+	// Never block the finalizer removal of an object being deleted
+	if !securityGroup.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	allErrs := v.validateSecurityGroup(securityGroup)
+
+	// Fields can still be fixed until the remote resource has been created
+	if oldSecurityGroup.Status.ResourceID != "" {
+		specPath := field.NewPath("spec")
+		allErrs = append(allErrs, validateTenant(specPath.Child("tenant"), securityGroup.Spec.Tenant, oldSecurityGroup.Spec.Tenant)...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(securityGroup.Spec.Location, oldSecurityGroup.Spec.Location, specPath.Child("location"))...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(securityGroup.Spec.VpcReference, oldSecurityGroup.Spec.VpcReference, specPath.Child("vpcReference"))...)
+	}
+
+	return nil, invalid("SecurityGroup", securityGroup.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type SecurityGroup.
+func (v *SecurityGroupCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	securityGroup, ok := obj.(*arubacloudcomv1alpha1.SecurityGroup)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityGroup object but got %T", obj)
+	}
+	securitygrouplog.Info("Validation for SecurityGroup upon deletion", "name", securityGroup.GetName())
+
+	return nil, nil
+}
+
+// validateSecurityGroup checks the fields of a SecurityGroup that must hold on create and update
+func (v *SecurityGroupCustomValidator) validateSecurityGroup(securityGroup *arubacloudcomv1alpha1.SecurityGroup) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, v.validateReference(specPath.Child("vpcReference"), securityGroup.Spec.VpcReference, securityGroup.Namespace)...)
+	allErrs = append(allErrs, v.validateReference(specPath.Child("projectReference"), securityGroup.Spec.ProjectReference, securityGroup.Namespace)...)
+	return allErrs
+}
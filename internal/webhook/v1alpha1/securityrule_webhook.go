@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var securityrulelog = logf.Log.WithName("securityrule-resource")
+
+// SetupSecurityRuleWebhookWithManager registers the webhook for SecurityRule in the manager.
+func SetupSecurityRuleWebhookWithManager(mgr ctrl.Manager, validationOptions ValidationOptions, defaultingOptions DefaultingOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.SecurityRule{}).
+		WithValidator(&SecurityRuleCustomValidator{ValidationOptions: validationOptions}).
+		WithDefaulter(&SecurityRuleCustomDefaulter{resourceDefaulter{client: mgr.GetClient(), DefaultingOptions: defaultingOptions}}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-securityrule,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=securityrules,verbs=create,versions=v1alpha1,name=msecurityrule-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecurityRuleCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind SecurityRule when those are created.
+type SecurityRuleCustomDefaulter struct {
+	resourceDefaulter
+}
+
+var _ webhook.CustomDefaulter = &SecurityRuleCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind SecurityRule.
+func (d *SecurityRuleCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	securityRule, ok := obj.(*arubacloudcomv1alpha1.SecurityRule)
+	if !ok {
+		return fmt.Errorf("expected a SecurityRule object but got %T", obj)
+	}
+	securityrulelog.Info("Defaulting for SecurityRule", "name", securityRule.GetName())
+
+	defaults, err := d.resolve(ctx, securityRule.Namespace, &securityRule.Spec.ProjectReference)
+	if err != nil {
+		return err
+	}
+
+	defaultString(&securityRule.Spec.Tenant, defaults.Tenant)
+	defaultString(&securityRule.Spec.Location.Value, defaults.Location)
+	defaultReference(&securityRule.Spec.SecurityGroupReference, securityRule.Namespace)
+	defaultReference(&securityRule.Spec.VpcReference, securityRule.Namespace)
+	defaultReference(&securityRule.Spec.ProjectReference, securityRule.Namespace)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-securityrule,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=securityrules,verbs=create;update,versions=v1alpha1,name=vsecurityrule-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SecurityRuleCustomValidator struct is responsible for validating the SecurityRule resource
+// when it is created, updated, or deleted.
+type SecurityRuleCustomValidator struct {
+	ValidationOptions
+}
+
+var _ webhook.CustomValidator = &SecurityRuleCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type SecurityRule.
+func (v *SecurityRuleCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	securityRule, ok := obj.(*arubacloudcomv1alpha1.SecurityRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityRule object but got %T", obj)
+	}
+	securityrulelog.Info("Validation for SecurityRule upon creation", "name", securityRule.GetName())
+
+	return nil, invalid("SecurityRule", securityRule.Name, v.validateSecurityRule(securityRule))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type SecurityRule.
+func (v *SecurityRuleCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	securityRule, ok := newObj.(*arubacloudcomv1alpha1.SecurityRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityRule object for the newObj but got %T", newObj)
+	}
+	oldSecurityRule, ok := oldObj.(*arubacloudcomv1alpha1.SecurityRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityRule object for the oldObj but got %T", oldObj)
+	}
+	securityrulelog.Info("Validation for SecurityRule upon update", "name", securityRule.GetName())
+
+	// Never block the finalizer removal of an object being deleted
+	if !securityRule.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	allErrs := v.validateSecurityRule(securityRule)
+
+	// Fields can still be fixed until the remote resource has been created
+	if oldSecurityRule.Status.ResourceID != "" {
+		specPath := field.NewPath("spec")
+		allErrs = append(allErrs, validateTenant(specPath.Child("tenant"), securityRule.Spec.Tenant, oldSecurityRule.Spec.Tenant)...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(securityRule.Spec.Location, oldSecurityRule.Spec.Location, specPath.Child("location"))...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(securityRule.Spec.VpcReference, oldSecurityRule.Spec.VpcReference, specPath.Child("vpcReference"))...)
+	}
+
+	return nil, invalid("SecurityRule", securityRule.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type SecurityRule.
+func (v *SecurityRuleCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	securityRule, ok := obj.(*arubacloudcomv1alpha1.SecurityRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a SecurityRule object but got %T", obj)
+	}
+	securityrulelog.Info("Validation for SecurityRule upon deletion", "name", securityRule.GetName())
+
+	return nil, nil
+}
+
+// validateSecurityRule checks the fields of a SecurityRule that must hold on create and update
+func (v *SecurityRuleCustomValidator) validateSecurityRule(securityRule *arubacloudcomv1alpha1.SecurityRule) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validatePort(specPath.Child("port"), securityRule.Spec.Port)...)
+	if securityRule.Spec.Target.Kind == "Ip" {
+		allErrs = append(allErrs, validateIPOrCIDR(specPath.Child("target", "value"), securityRule.Spec.Target.Value)...)
+	}
+	allErrs = append(allErrs, v.validateReference(specPath.Child("securityGroupReference"), securityRule.Spec.SecurityGroupReference, securityRule.Namespace)...)
+	allErrs = append(allErrs, v.validateReference(specPath.Child("vpcReference"), securityRule.Spec.VpcReference, securityRule.Namespace)...)
+	allErrs = append(allErrs, v.validateReference(specPath.Child("projectReference"), securityRule.Spec.ProjectReference, securityRule.Namespace)...)
+	return allErrs
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("SecurityRule Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.SecurityRule
+		validator SecurityRuleCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.SecurityRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-securityrule",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.SecurityRuleSpec{
+				Tenant:    "test-tenant",
+				Protocol:  "TCP",
+				Port:      "80",
+				Direction: "Ingress",
+				Target: arubacloudcomv1alpha1.SecurityRuleTarget{
+					Kind:  "Ip",
+					Value: "0.0.0.0/0",
+				},
+				SecurityGroupReference: arubacloudcomv1alpha1.ResourceReference{Name: "test-securitygroup"},
+				VpcReference:           arubacloudcomv1alpha1.ResourceReference{Name: "test-vpc"},
+				ProjectReference:       arubacloudcomv1alpha1.ResourceReference{Name: "test-project"},
+			},
+		}
+		validator = SecurityRuleCustomValidator{}
+	})
+
+	Context("When creating SecurityRule under Validating Webhook", func() {
+		DescribeTable("port syntax",
+			func(port string, valid bool) {
+				obj.Spec.Port = port
+				_, err := validator.ValidateCreate(ctx, obj)
+				if valid {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring("spec.port")))
+				}
+			},
+			Entry("single port", "80", true),
+			Entry("port range", "80-90", true),
+			Entry("all ports", "ALL", true),
+			Entry("reversed range", "90-80", false),
+			Entry("out of range", "65536", false),
+			Entry("zero", "0", false),
+			Entry("not a number", "http", false),
+			Entry("open range", "80-", false),
+		)
+
+		It("Should deny an Ip target that is not an IP or CIDR", func() {
+			obj.Spec.Target.Value = "not-an-ip"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.target.value")))
+		})
+
+		It("Should admit a single IP as Ip target", func() {
+			obj.Spec.Target.Value = "192.168.1.10"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should not check the value of a SecurityGroup target as an IP", func() {
+			obj.Spec.Target = arubacloudcomv1alpha1.SecurityRuleTarget{Kind: "SecurityGroup", Value: "test-securitygroup"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var subnetlog = logf.Log.WithName("subnet-resource")
+
+// SetupSubnetWebhookWithManager registers the webhook for Subnet in the manager.
+func SetupSubnetWebhookWithManager(mgr ctrl.Manager, validationOptions ValidationOptions, defaultingOptions DefaultingOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.Subnet{}).
+		WithValidator(&SubnetCustomValidator{ValidationOptions: validationOptions}).
+		WithDefaulter(&SubnetCustomDefaulter{resourceDefaulter{client: mgr.GetClient(), DefaultingOptions: defaultingOptions}}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-subnet,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=subnets,verbs=create,versions=v1alpha1,name=msubnet-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SubnetCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind Subnet when those are created.
+type SubnetCustomDefaulter struct {
+	resourceDefaulter
+}
+
+var _ webhook.CustomDefaulter = &SubnetCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind Subnet.
+func (d *SubnetCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	subnet, ok := obj.(*arubacloudcomv1alpha1.Subnet)
+	if !ok {
+		return fmt.Errorf("expected a Subnet object but got %T", obj)
+	}
+	subnetlog.Info("Defaulting for Subnet", "name", subnet.GetName())
+
+	defaults, err := d.resolve(ctx, subnet.Namespace, &subnet.Spec.ProjectReference)
+	if err != nil {
+		return err
+	}
+
+	defaultString(&subnet.Spec.Tenant, defaults.Tenant)
+	defaultReference(&subnet.Spec.VpcReference, subnet.Namespace)
+	defaultReference(&subnet.Spec.ProjectReference, subnet.Namespace)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-subnet,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=subnets,verbs=create;update,versions=v1alpha1,name=vsubnet-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SubnetCustomValidator struct is responsible for validating the Subnet resource
+// when it is created, updated, or deleted.
+type SubnetCustomValidator struct {
+	ValidationOptions
+}
+
+var _ webhook.CustomValidator = &SubnetCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Subnet.
+func (v *SubnetCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	subnet, ok := obj.(*arubacloudcomv1alpha1.Subnet)
+	if !ok {
+		return nil, fmt.Errorf("expected a Subnet object but got %T", obj)
+	}
+	subnetlog.Info("Validation for Subnet upon creation", "name", subnet.GetName())
+
+	return nil, invalid("Subnet", subnet.Name, v.validateSubnet(subnet))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Subnet.
+func (v *SubnetCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	subnet, ok := newObj.(*arubacloudcomv1alpha1.Subnet)
+	if !ok {
+		return nil, fmt.Errorf("expected a Subnet object for the newObj but got %T", newObj)
+	}
+	oldSubnet, ok := oldObj.(*arubacloudcomv1alpha1.Subnet)
+	if !ok {
+		return nil, fmt.Errorf("expected a Subnet object for the oldObj but got %T", oldObj)
+	}
+	subnetlog.Info("Validation for Subnet upon update", "name", subnet.GetName())
+
+	// Never block the finalizer removal of an object being deleted
+	if !subnet.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	allErrs := v.validateSubnet(subnet)
+
+	// Fields can still be fixed until the remote resource has been created
+	if oldSubnet.Status.ResourceID != "" {
+		specPath := field.NewPath("spec")
+		allErrs = append(allErrs, validateTenant(specPath.Child("tenant"), subnet.Spec.Tenant, oldSubnet.Spec.Tenant)...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(subnet.Spec.VpcReference, oldSubnet.Spec.VpcReference, specPath.Child("vpcReference"))...)
+	}
+
+	return nil, invalid("Subnet", subnet.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Subnet.
+func (v *SubnetCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	subnet, ok := obj.(*arubacloudcomv1alpha1.Subnet)
+	if !ok {
+		return nil, fmt.Errorf("expected a Subnet object but got %T", obj)
+	}
+	subnetlog.Info("Validation for Subnet upon deletion", "name", subnet.GetName())
+
+	return nil, nil
+}
+
+// validateSubnet checks the fields of a Subnet that must hold on create and update
+func (v *SubnetCustomValidator) validateSubnet(subnet *arubacloudcomv1alpha1.Subnet) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateCIDR(specPath.Child("network", "address"), subnet.Spec.Network.Address)...)
+	allErrs = append(allErrs, v.validateReference(specPath.Child("vpcReference"), subnet.Spec.VpcReference, subnet.Namespace)...)
+	allErrs = append(allErrs, v.validateReference(specPath.Child("projectReference"), subnet.Spec.ProjectReference, subnet.Namespace)...)
+	return allErrs
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Subnet Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *arubacloudcomv1alpha1.Subnet
+		oldObj    *arubacloudcomv1alpha1.Subnet
+		validator SubnetCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &arubacloudcomv1alpha1.Subnet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-subnet",
+				Namespace: "default",
+			},
+			Spec: arubacloudcomv1alpha1.SubnetSpec{
+				Tenant: "test-tenant",
+				Type:   "Advanced",
+				Network: arubacloudcomv1alpha1.SubnetNetwork{
+					Address: "10.0.0.0/24",
+				},
+				VpcReference: arubacloudcomv1alpha1.ResourceReference{
+					Name: "test-vpc",
+				},
+				ProjectReference: arubacloudcomv1alpha1.ResourceReference{
+					Name:      "test-project",
+					Namespace: "default",
+				},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = SubnetCustomValidator{}
+	})
+
+	Context("When creating Subnet under Validating Webhook", func() {
+		It("Should admit a valid subnet", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny an invalid CIDR", func() {
+			obj.Spec.Network.Address = "10.0.0.300/24"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.network.address")))
+		})
+
+		It("Should deny references to other namespaces unless allowed", func() {
+			obj.Spec.VpcReference.Namespace = "other"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.vpcReference.namespace")))
+
+			validator.AllowCrossNamespaceReferences = true
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When updating Subnet under Validating Webhook", func() {
+		It("Should allow changing the vpc reference before the subnet is created", func() {
+			obj.Spec.VpcReference.Name = "other-vpc"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny changing the vpc reference once the subnet is created", func() {
+			oldObj.Status.ResourceID = "subnet-123"
+			obj.Spec.VpcReference.Name = "other-vpc"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(MatchError(ContainSubstring("spec.vpcReference")))
+		})
+
+		It("Should deny changing the tenant once the subnet is created", func() {
+			oldObj.Status.ResourceID = "subnet-123"
+			obj.Spec.Tenant = "other-tenant"
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(MatchError(ContainSubstring("spec.tenant")))
+		})
+	})
+})
@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// portAll is the SecurityRule port value matching every port
+const portAll = "ALL"
+
+// ValidationOptions holds the operator settings shared by all validating webhooks
+type ValidationOptions struct {
+	// AllowCrossNamespaceReferences allows references to resources in other namespaces
+	AllowCrossNamespaceReferences bool
+}
+
+// validateReference checks that a reference stays in the namespace of the referencing object
+func (o ValidationOptions) validateReference(path *field.Path, ref arubacloudcomv1alpha1.ResourceReference, namespace string) field.ErrorList {
+	if o.AllowCrossNamespaceReferences || ref.Namespace == "" || ref.Namespace == namespace {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(path.Child("namespace"), "references to resources in other namespaces are not allowed")}
+}
+
+// validateReferences checks a list of references with validateReference
+func (o ValidationOptions) validateReferences(path *field.Path, refs []arubacloudcomv1alpha1.ResourceReference, namespace string) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, ref := range refs {
+		allErrs = append(allErrs, o.validateReference(path.Index(i), ref, namespace)...)
+	}
+	return allErrs
+}
+
+// validateTenant rejects changing the tenant once set, since remote resources belong to it
+func validateTenant(path *field.Path, newTenant, oldTenant string) field.ErrorList {
+	if oldTenant == "" {
+		return nil
+	}
+	return apimachineryvalidation.ValidateImmutableField(newTenant, oldTenant, path)
+}
+
+// validateCIDR checks that value is an IP network in CIDR notation
+func validateCIDR(path *field.Path, value string) field.ErrorList {
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		return field.ErrorList{field.Invalid(path, value, "must be a valid CIDR, e.g. 10.0.0.0/24")}
+	}
+	return nil
+}
+
+// validateIPOrCIDR checks that value is an IP address or an IP network in CIDR notation
+func validateIPOrCIDR(path *field.Path, value string) field.ErrorList {
+	if net.ParseIP(value) != nil {
+		return nil
+	}
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		return field.ErrorList{field.Invalid(path, value, "must be a valid IP address or CIDR")}
+	}
+	return nil
+}
+
+// validatePort checks the SecurityRule port syntax: a single port ("80"), a range ("80-90") or "ALL"
+func validatePort(path *field.Path, value string) field.ErrorList {
+	if value == portAll {
+		return nil
+	}
+
+	low, high, isRange := strings.Cut(value, "-")
+	if !isRange {
+		high = low
+	}
+	lowPort, lowErr := parsePort(low)
+	highPort, highErr := parsePort(high)
+	if lowErr != nil || highErr != nil {
+		return field.ErrorList{field.Invalid(path, value, `must be a port between 1 and 65535, a range such as "80-90" or "ALL"`)}
+	}
+	if lowPort > highPort {
+		return field.ErrorList{field.Invalid(path, value, "port range start must not be greater than its end")}
+	}
+	return nil
+}
+
+// parsePort parses a port number between 1 and 65535
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port %d out of range", port)
+	}
+	return port, nil
+}
+
+// invalid converts field errors into an Invalid API error for the object, or nil if there are none
+func invalid(kind, name string, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(arubacloudcomv1alpha1.GroupVersion.WithKind(kind).GroupKind(), name, allErrs)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arubacloudcomv1alpha1 "github.com/Arubacloud/arubacloud-resource-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var vpclog = logf.Log.WithName("vpc-resource")
+
+// SetupVpcWebhookWithManager registers the webhook for Vpc in the manager.
+func SetupVpcWebhookWithManager(mgr ctrl.Manager, validationOptions ValidationOptions, defaultingOptions DefaultingOptions) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&arubacloudcomv1alpha1.Vpc{}).
+		WithValidator(&VpcCustomValidator{ValidationOptions: validationOptions}).
+		WithDefaulter(&VpcCustomDefaulter{resourceDefaulter{client: mgr.GetClient(), DefaultingOptions: defaultingOptions}}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-arubacloud-com-v1alpha1-vpc,mutating=true,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=vpcs,verbs=create,versions=v1alpha1,name=mvpc-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// VpcCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind Vpc when those are created.
+type VpcCustomDefaulter struct {
+	resourceDefaulter
+}
+
+var _ webhook.CustomDefaulter = &VpcCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind Vpc.
+func (d *VpcCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	vpc, ok := obj.(*arubacloudcomv1alpha1.Vpc)
+	if !ok {
+		return fmt.Errorf("expected a Vpc object but got %T", obj)
+	}
+	vpclog.Info("Defaulting for Vpc", "name", vpc.GetName())
+
+	defaults, err := d.resolve(ctx, vpc.Namespace, &vpc.Spec.ProjectReference)
+	if err != nil {
+		return err
+	}
+
+	defaultString(&vpc.Spec.Tenant, defaults.Tenant)
+	defaultString(&vpc.Spec.Location.Value, defaults.Location)
+	defaultReference(&vpc.Spec.ProjectReference, vpc.Namespace)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-arubacloud-com-v1alpha1-vpc,mutating=false,failurePolicy=fail,sideEffects=None,groups=arubacloud.com,resources=vpcs,verbs=create;update,versions=v1alpha1,name=vvpc-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// VpcCustomValidator struct is responsible for validating the Vpc resource
+// when it is created, updated, or deleted.
+type VpcCustomValidator struct {
+	ValidationOptions
+}
+
+var _ webhook.CustomValidator = &VpcCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Vpc.
+func (v *VpcCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	vpc, ok := obj.(*arubacloudcomv1alpha1.Vpc)
+	if !ok {
+		return nil, fmt.Errorf("expected a Vpc object but got %T", obj)
+	}
+	vpclog.Info("Validation for Vpc upon creation", "name", vpc.GetName())
+
+	return nil, invalid("Vpc", vpc.Name, v.validateVpc(vpc))
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Vpc.
+func (v *VpcCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	vpc, ok := newObj.(*arubacloudcomv1alpha1.Vpc)
+	if !ok {
+		return nil, fmt.Errorf("expected a Vpc object for the newObj but got %T", newObj)
+	}
+	oldVpc, ok := oldObj.(*arubacloudcomv1alpha1.Vpc)
+	if !ok {
+		return nil, fmt.Errorf("expected a Vpc object for the oldObj but got %T", oldObj)
+	}
+	vpclog.Info("Validation for Vpc upon update", "name", vpc.GetName())
+
+	// Never block the finalizer removal of an object being deleted
+	if !vpc.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	allErrs := v.validateVpc(vpc)
+
+	// Fields can still be fixed until the remote resource has been created
+	if oldVpc.Status.ResourceID != "" {
+		specPath := field.NewPath("spec")
+		allErrs = append(allErrs, validateTenant(specPath.Child("tenant"), vpc.Spec.Tenant, oldVpc.Spec.Tenant)...)
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(vpc.Spec.Location, oldVpc.Spec.Location, specPath.Child("location"))...)
+	}
+
+	return nil, invalid("Vpc", vpc.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Vpc.
+func (v *VpcCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	vpc, ok := obj.(*arubacloudcomv1alpha1.Vpc)
+	if !ok {
+		return nil, fmt.Errorf("expected a Vpc object but got %T", obj)
+	}
+	vpclog.Info("Validation for Vpc upon deletion", "name", vpc.GetName())
+
+	return nil, nil
+}
+
+// validateVpc checks the fields of a Vpc that must hold on create and update
+func (v *VpcCustomValidator) validateVpc(vpc *arubacloudcomv1alpha1.Vpc) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, v.validateReference(specPath.Child("projectReference"), vpc.Spec.ProjectReference, vpc.Namespace)...)
+	return allErrs
+}